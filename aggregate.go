@@ -0,0 +1,72 @@
+package timeseriesgo
+
+import "math"
+
+// Aggregator reduces the values of one bucket (never empty, in time order) to a single value.
+//
+// NaN values are handled in one of two ways. The order statistics (AggregateMedian and
+// AggregatePercentile) ignore them, as NaN has no place in a sorted bucket. All the other
+// aggregators take every value as it is: NaN propagates through the sum, mean, minimum,
+// maximum and standard deviation, AggregateFirst and AggregateLast may return it, and
+// AggregateCount counts it. Drop NaN beforehand (e.g. with Filter) for NaN-free buckets.
+type Aggregator func(values []float64) float64
+
+var (
+	// AggregateMean is the arithmetic mean of the bucket; NaN if any value is NaN.
+	AggregateMean Aggregator = func(vs []float64) float64 { return AggregateSum(vs) / float64(len(vs)) }
+	// AggregateSum is the sum of the bucket; NaN if any value is NaN.
+	AggregateSum Aggregator = func(vs []float64) float64 {
+		total := 0.0
+		for _, v := range vs {
+			total += v
+		}
+		return total
+	}
+	// AggregateMin is the smallest value of the bucket; NaN if any value is NaN.
+	AggregateMin Aggregator = func(vs []float64) float64 {
+		m := vs[0]
+		for _, v := range vs[1:] {
+			m = math.Min(m, v)
+		}
+		return m
+	}
+	// AggregateMax is the largest value of the bucket; NaN if any value is NaN.
+	AggregateMax Aggregator = func(vs []float64) float64 {
+		m := vs[0]
+		for _, v := range vs[1:] {
+			m = math.Max(m, v)
+		}
+		return m
+	}
+	// AggregateFirst is the earliest value of the bucket.
+	AggregateFirst Aggregator = func(vs []float64) float64 { return vs[0] }
+	// AggregateLast is the latest value of the bucket.
+	AggregateLast Aggregator = func(vs []float64) float64 { return vs[len(vs)-1] }
+	// AggregateCount is the number of points in the bucket, NaN values included.
+	AggregateCount Aggregator = func(vs []float64) float64 { return float64(len(vs)) }
+	// AggregateMedian is the middle value of the bucket (the mean of the two middle values for even sizes), ignoring NaN.
+	AggregateMedian Aggregator = AggregatePercentile(50)
+	// AggregateStdDev is the sample standard deviation of the bucket; NaN for a single value or if any value is NaN.
+	AggregateStdDev Aggregator = func(vs []float64) float64 {
+		if len(vs) < 2 {
+			return math.NaN()
+		}
+		mean := AggregateMean(vs)
+		ss := 0.0
+		for _, v := range vs {
+			ss += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(ss / float64(len(vs)-1))
+	}
+)
+
+/**
+ * Returns an aggregator for the p-th percentile (0-100) of a bucket, interpolating
+ * between order statistics at rank p*(n+1)/100 and clamping to the extremes.
+ * NaN values are ignored; a bucket holding only NaN gives NaN.
+ */
+func AggregatePercentile(p float64) Aggregator {
+	return func(vs []float64) float64 {
+		return QuantileOfSorted(sortedValues(vs), math.Min(math.Max(p, 0), 100)/100, QuantileType6)
+	}
+}
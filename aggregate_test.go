@@ -0,0 +1,67 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAggregators(t *testing.T) {
+	vs := []float64{4, 1, 3, 2}
+	cases := []struct {
+		name string
+		agg  Aggregator
+		want float64
+	}{
+		{"sum", AggregateSum, 10},
+		{"mean", AggregateMean, 2.5},
+		{"median", AggregateMedian, 2.5},
+		{"min", AggregateMin, 1},
+		{"max", AggregateMax, 4},
+		{"first", AggregateFirst, 4},
+		{"last", AggregateLast, 2},
+		{"count", AggregateCount, 4},
+		{"stddev", AggregateStdDev, math.Sqrt(5.0 / 3.0)},
+		{"p25", AggregatePercentile(25), 1.25},
+		{"p100", AggregatePercentile(100), 4},
+	}
+	for _, c := range cases {
+		if got := c.agg(vs); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+	if vs[0] != 4 {
+		t.Errorf("aggregators must not reorder their input")
+	}
+	if !math.IsNaN(AggregateStdDev([]float64{1})) {
+		t.Errorf("expected NaN standard deviation for a single value")
+	}
+}
+
+func TestAggregatorNaNPolicy(t *testing.T) {
+	nan := math.NaN()
+	if got := AggregateMedian([]float64{nan, 1, 2}); got != 1.5 {
+		t.Errorf("expected median 1.5, got %v", got)
+	}
+	if got := AggregatePercentile(100)([]float64{3, nan, 1}); got != 3 {
+		t.Errorf("expected max 3, got %v", got)
+	}
+	if got := AggregateMedian([]float64{nan, nan}); !math.IsNaN(got) {
+		t.Errorf("expected NaN for a bucket without values, got %v", got)
+	}
+	// Every other aggregator takes NaN as a value.
+	withNaN := []float64{1, nan, 3}
+	for name, agg := range map[string]Aggregator{
+		"sum": AggregateSum, "mean": AggregateMean, "min": AggregateMin,
+		"max": AggregateMax, "stddev": AggregateStdDev, "first": AggregateFirst,
+	} {
+		if got := agg([]float64{nan, 1, 3}); !math.IsNaN(got) {
+			t.Errorf("%s: expected NaN to propagate, got %v", name, got)
+		}
+		if got := agg(withNaN); name != "first" && !math.IsNaN(got) {
+			t.Errorf("%s: expected NaN to propagate from the middle, got %v", name, got)
+		}
+	}
+	if got := AggregateCount(withNaN); got != 3 {
+		t.Errorf("expected count to include NaN, got %v", got)
+	}
+}
@@ -14,10 +14,21 @@ type DoubleDataPoint struct {
 type AlignedSeries struct {
 	datapoints []DoubleDataPoint
 	label      string
+	// base is an empty series carrying what results inherit from the left series: its
+	// tags, unit and location. Results take their name from label.
+	base TimeSeries
 }
 
 func EmptyLabeledAlignedSeries(label string) AlignedSeries {
-	return AlignedSeries{datapoints: []DoubleDataPoint{}, label: label}
+	return AlignedSeries{datapoints: []DoubleDataPoint{}, label: label, base: EmptyLabeled(label)}
+}
+
+// alignedFrom starts an aligned series whose results inherit the metadata of left.
+func alignedFrom(left *TimeSeries, label string) AlignedSeries {
+	res := EmptyLabeledAlignedSeries(label)
+	res.base = left.derive()
+	res.base.label = label
+	return res
 }
 
 /**
@@ -34,13 +45,18 @@ func (ts *AlignedSeries) Length() int {
 	return len(ts.datapoints)
 }
 
+/**
+ * Reduces every pair to one value. The result is named after the aligned series (the join,
+ * unless renamed with SetLabel) and keeps the tags and unit of the left series.
+ */
 func (ts *AlignedSeries) MapValuesWithReduce(f func(float64, float64) float64) TimeSeries {
-	mapped := Empty()
+	mapped := ts.base.derive()
+	mapped.label = ts.label
+	if mapped.loc == nil && len(ts.datapoints) > 0 {
+		mapped.loc = ts.datapoints[0].Timestamp.Location()
+	}
 	for _, dp := range ts.datapoints {
-		mapped.AddPoint(DataPoint{
-			Timestamp: dp.Timestamp,
-			Value:     f(dp.LeftValue, dp.RightValue),
-		})
+		mapped.appendRaw(dp.Timestamp.UnixNano(), f(dp.LeftValue, dp.RightValue))
 	}
 	return mapped
 }
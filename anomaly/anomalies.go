@@ -27,6 +27,7 @@ func ZScore(ts timeseriesgo.TimeSeries) (timeseriesgo.TimeSeries, error) {
 	mean := mv.Mean
 	stddev := math.Sqrt(mv.SampleVariance)
 	zscored := timeseriesgo.Empty()
+	zscored.SetMetadata(ts.Metadata())
 	for _, dp := range ts.DataPoints() {
 		zscored.AddPoint(timeseriesgo.DataPoint{
 			Timestamp: dp.Timestamp,
@@ -108,6 +109,7 @@ func FindSpikeAnomalies(ts timeseriesgo.TimeSeries, threshold float64) (timeseri
 	}
 
 	res := timeseriesgo.Empty()
+	res.SetMetadata(ts.Metadata())
 	for i, dp := range points {
 		res.AddPoint(timeseriesgo.DataPoint{
 			Timestamp: dp.Timestamp,
@@ -136,6 +138,7 @@ func FindDropAnomalies(ts timeseriesgo.TimeSeries, threshold float64) (timeserie
 	}
 
 	res := timeseriesgo.Empty()
+	res.SetMetadata(ts.Metadata())
 	for i, dp := range points {
 		res.AddPoint(timeseriesgo.DataPoint{
 			Timestamp: dp.Timestamp,
@@ -184,6 +187,7 @@ func FindFlatlineAnomalies(ts timeseriesgo.TimeSeries, tolerance float64, minLen
 	}
 
 	res := timeseriesgo.Empty()
+	res.SetMetadata(ts.Metadata())
 	for i, dp := range points {
 		res.AddPoint(timeseriesgo.DataPoint{
 			Timestamp: dp.Timestamp,
@@ -192,3 +196,10 @@ func FindFlatlineAnomalies(ts timeseriesgo.TimeSeries, tolerance float64, minLen
 	}
 	return res, nil
 }
+
+// AsFlags turns the 0/1 series returned by the detectors into a Series[bool].
+func AsFlags(flags timeseriesgo.TimeSeries) timeseriesgo.Series[bool] {
+	return timeseriesgo.MapSeries(flags.AsSeries(), func(v float64) bool {
+		return v != 0
+	})
+}
@@ -141,3 +141,23 @@ func TestFindFlatlineAnomalies(t *testing.T) {
 		}
 	}
 }
+
+func TestAsFlags(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := timeseriesgo.Empty()
+	for i, v := range []float64{1, 1, 5, 1} {
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: base.Add(time.Duration(i) * time.Minute), Value: v})
+	}
+	spikes, err := FindSpikeAnomalies(ts, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	flags := AsFlags(spikes)
+	expected := []bool{false, false, true, false}
+	for i, v := range flags.Values() {
+		if v != expected[i] {
+			t.Errorf("At index %d: expected flag %v, got %v", i, expected[i], v)
+		}
+	}
+}
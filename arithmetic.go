@@ -0,0 +1,130 @@
+package timeseriesgo
+
+import (
+	"math"
+	"time"
+)
+
+type alignKind int
+
+const (
+	alignInner alignKind = iota
+	alignLeft
+	alignOuter
+	alignAsOf
+)
+
+// Alignment decides which points of two series are paired by Combine and the arithmetic
+// operations, and what stands in for a missing partner. Build one with AlignInner, AlignLeft,
+// AlignOuter or AlignAsOf; the zero value is AlignInner.
+type Alignment struct {
+	kind      alignKind
+	fill      float64
+	direction AsOfDirection
+	tolerance time.Duration
+}
+
+// AlignInner pairs points with equal timestamps and drops the rest, like Join.
+func AlignInner() Alignment {
+	return Alignment{kind: alignInner}
+}
+
+// AlignLeft keeps every point of the left series; a missing right value is fill, like JoinLeft.
+func AlignLeft(fill float64) Alignment {
+	return Alignment{kind: alignLeft, fill: fill}
+}
+
+// AlignOuter keeps the timestamps of both series; a missing value on either side is fill, like JoinOuter.
+// Use math.NaN() to make unmatched points visible in the result instead of silently dropping them.
+func AlignOuter(fill float64) Alignment {
+	return Alignment{kind: alignOuter, fill: fill}
+}
+
+// AlignAsOf pairs every left point with the nearest right point in direction within tolerance,
+// like JoinAsOf. Left points without a match are dropped.
+func AlignAsOf(direction AsOfDirection, tolerance time.Duration) Alignment {
+	return Alignment{kind: alignAsOf, direction: direction, tolerance: tolerance}
+}
+
+// align joins ts and other as the policy says.
+func (ts *TimeSeries) align(other TimeSeries, policy Alignment) AlignedSeries {
+	switch policy.kind {
+	case alignLeft:
+		return ts.JoinLeft(other, policy.fill)
+	case alignOuter:
+		return ts.JoinOuter(other, policy.fill, policy.fill)
+	case alignAsOf:
+		return ts.JoinAsOf(other, policy.direction, policy.tolerance)
+	}
+	return ts.Join(other)
+}
+
+/**
+ * Combines two sorted series point by point.
+ * Example: errors.Combine(requests, func(e, r float64) float64 { return e / r }, AlignInner())
+ *
+ * @param other The right-hand series.
+ * @param f Computes the result from the left and right values.
+ * @param policy Which points are paired and how missing partners are filled.
+ *
+ * @return A TimeSeries at the aligned timestamps, keeping the metadata of ts.
+ */
+func (ts *TimeSeries) Combine(other TimeSeries, f func(l, r float64) float64, policy Alignment) TimeSeries {
+	res := ts.derive()
+	for _, dp := range ts.align(other, policy).datapoints {
+		res.appendRaw(dp.Timestamp.UnixNano(), f(dp.LeftValue, dp.RightValue))
+	}
+	return res
+}
+
+// Add returns ts + other, see Combine.
+func (ts *TimeSeries) Add(other TimeSeries, policy Alignment) TimeSeries {
+	return ts.Combine(other, func(l, r float64) float64 { return l + r }, policy)
+}
+
+// Sub returns ts - other, see Combine.
+func (ts *TimeSeries) Sub(other TimeSeries, policy Alignment) TimeSeries {
+	return ts.Combine(other, func(l, r float64) float64 { return l - r }, policy)
+}
+
+// Mul returns ts * other, see Combine.
+func (ts *TimeSeries) Mul(other TimeSeries, policy Alignment) TimeSeries {
+	return ts.Combine(other, func(l, r float64) float64 { return l * r }, policy)
+}
+
+// Div returns ts / other, see Combine. Division by zero gives NaN.
+func (ts *TimeSeries) Div(other TimeSeries, policy Alignment) TimeSeries {
+	return ts.DivOr(other, policy, math.NaN())
+}
+
+// DivOr is Div with onZero as the result of a division by zero, e.g. 0 for an error ratio without requests.
+func (ts *TimeSeries) DivOr(other TimeSeries, policy Alignment, onZero float64) TimeSeries {
+	return ts.Combine(other, func(l, r float64) float64 { return safeDiv(l, r, onZero) }, policy)
+}
+
+func safeDiv(l, r, onZero float64) float64 {
+	if r == 0 {
+		return onZero
+	}
+	return l / r
+}
+
+// AddScalar returns ts + c.
+func (ts *TimeSeries) AddScalar(c float64) TimeSeries {
+	return ts.MapValues(func(v float64) float64 { return v + c })
+}
+
+// SubScalar returns ts - c.
+func (ts *TimeSeries) SubScalar(c float64) TimeSeries {
+	return ts.MapValues(func(v float64) float64 { return v - c })
+}
+
+// MulScalar returns ts * c.
+func (ts *TimeSeries) MulScalar(c float64) TimeSeries {
+	return ts.MapValues(func(v float64) float64 { return v * c })
+}
+
+// DivScalar returns ts / c; every value is NaN when c is zero.
+func (ts *TimeSeries) DivScalar(c float64) TimeSeries {
+	return ts.MapValues(func(v float64) float64 { return safeDiv(v, c, math.NaN()) })
+}
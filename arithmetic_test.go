@@ -0,0 +1,54 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeriesArithmetic(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := minuteSeries(base, []int{0, 1, 2}, []float64{6, 8, 10})
+	b := minuteSeries(base, []int{1, 2, 3}, []float64{2, 0, 5})
+	a.SetLabel("errors")
+	nan := math.NaN()
+
+	sum := a.Add(b, AlignInner())
+	assertSeriesValues(t, "add", sum, []float64{10, 10})
+	if sum.Label() != "errors" {
+		t.Errorf("expected the left label, got %q", sum.Label())
+	}
+	assertSeriesValues(t, "sub", a.Sub(b, AlignInner()), []float64{6, 10})
+	assertSeriesValues(t, "mul", a.Mul(b, AlignLeft(1)), []float64{6, 16, 0})
+	assertSeriesValues(t, "div", a.Div(b, AlignInner()), []float64{4, nan})
+	assertSeriesValues(t, "div or", a.DivOr(b, AlignInner(), 0), []float64{4, 0})
+
+	outer := a.Sub(b, AlignOuter(nan))
+	assertSeriesValues(t, "outer", outer, []float64{nan, 6, 10, nan})
+	if outer.Length() != 4 {
+		t.Errorf("expected unmatched points to be kept, got %d", outer.Length())
+	}
+
+	var zero Alignment
+	assertSeriesValues(t, "zero value", a.Add(b, zero), []float64{10, 10})
+}
+
+func TestCombineAsOf(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	trades := minuteSeries(base, []int{1, 3, 7}, []float64{101, 103, 99})
+	quotes := minuteSeries(base, []int{0, 2, 5}, []float64{100, 102, 100})
+	spread := trades.Combine(quotes, func(trade, quote float64) float64 { return trade - quote }, AlignAsOf(AsOfBackward, 90*time.Second))
+	// 00:07 has no quote within 90s and is dropped.
+	assertSeriesValues(t, "as-of", spread, []float64{1, 1})
+}
+
+func TestScalarArithmetic(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1}, []float64{2, 4})
+	nan := math.NaN()
+	assertSeriesValues(t, "add", ts.AddScalar(1), []float64{3, 5})
+	assertSeriesValues(t, "sub", ts.SubScalar(1), []float64{1, 3})
+	assertSeriesValues(t, "mul", ts.MulScalar(0.5), []float64{1, 2})
+	assertSeriesValues(t, "div", ts.DivScalar(2), []float64{1, 2})
+	assertSeriesValues(t, "div zero", ts.DivScalar(0), []float64{nan, nan})
+}
@@ -0,0 +1,207 @@
+package calendar
+
+import (
+	"time"
+
+	timeseriesgo "github.com/wenta/timeseries-go"
+)
+
+// maxSearchDays bounds the search for the next business day, so a calendar that closes
+// every day cannot loop forever.
+const maxSearchDays = 3660
+
+// Rule computes a holiday for a given year, e.g. the last Monday of May.
+type Rule func(year int) (time.Month, int)
+
+// date is a day on the wall clock, independent of location.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+// before reports whether a is an earlier day than b.
+func before(a, b date) bool {
+	if a.year != b.year {
+		return a.year < b.year
+	}
+	if a.month != b.month {
+		return a.month < b.month
+	}
+	return a.day < b.day
+}
+
+// Calendar describes business days: weekdays that are not weekend days, fixed or
+// rule-based holidays, or ad-hoc closures. Days are taken from the wall clock of the
+// timestamp asked about. A Calendar is a timeseriesgo.Frequency stepping one business day,
+// so it can drive generator.MakeSeriesIndexWithFrequency, Downsample and the other
+// frequency-based APIs.
+type Calendar struct {
+	name     string
+	weekend  [7]bool
+	fixed    map[date]bool // year is 0: recurs every year
+	rules    []Rule
+	closures map[date]bool
+}
+
+/**
+ * Creates a calendar with the given weekend days and no holidays.
+ *
+ * @param name The name of the calendar, returned by String.
+ * @param weekend The days that are never business days; none given means Saturday and Sunday.
+ */
+func New(name string, weekend ...time.Weekday) *Calendar {
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+	c := &Calendar{name: name, fixed: map[date]bool{}, closures: map[date]bool{}}
+	for _, wd := range weekend {
+		c.weekend[wd] = true
+	}
+	return c
+}
+
+// AddFixedHoliday adds a holiday on the same date every year, e.g. December 25th.
+func (c *Calendar) AddFixedHoliday(month time.Month, day int) *Calendar {
+	c.fixed[date{0, month, day}] = true
+	return c
+}
+
+// AddRule adds a holiday computed per year, e.g. NthWeekday(time.November, time.Thursday, 4).
+func (c *Calendar) AddRule(rule Rule) *Calendar {
+	c.rules = append(c.rules, rule)
+	return c
+}
+
+// AddClosure adds one-off closed days; only the date of each timestamp is used.
+func (c *Calendar) AddClosure(days ...time.Time) *Calendar {
+	for _, t := range days {
+		c.closures[dateOf(t)] = true
+	}
+	return c
+}
+
+/**
+ * Returns a rule for the n-th weekday of a month; n = -1 is the last one.
+ * Example: NthWeekday(time.May, time.Monday, -1) is the last Monday of May.
+ */
+func NthWeekday(month time.Month, weekday time.Weekday, n int) Rule {
+	return func(year int) (time.Month, int) {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			back := (int(last.Weekday()) - int(weekday) + 7) % 7
+			return month, last.Day() - back
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return month, 1 + ahead + 7*(n-1)
+	}
+}
+
+/**
+ * Returns a rule for the day offset days after Western (Gregorian) Easter Sunday,
+ * e.g. -2 for Good Friday and 1 for Easter Monday.
+ */
+func EasterOffset(offset int) Rule {
+	return func(year int) (time.Month, int) {
+		// Anonymous Gregorian algorithm.
+		a := year % 19
+		b, c := year/100, year%100
+		d, e := b/4, b%4
+		f := (b + 8) / 25
+		g := (b - f + 1) / 3
+		h := (19*a + b - d - g + 15) % 30
+		i, k := c/4, c%4
+		l := (32 + 2*e + 2*i - h - k) % 7
+		m := (a + 11*h + 22*l) / 451
+		month := (h + l - 7*m + 114) / 31
+		day := (h+l-7*m+114)%31 + 1
+		easter := time.Date(year, time.Month(month), day+offset, 0, 0, 0, 0, time.UTC)
+		return easter.Month(), easter.Day()
+	}
+}
+
+// IsHoliday reports whether the date of t is a fixed holiday, a rule-based holiday or a closure.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	d := dateOf(t)
+	if c.closures[d] || c.fixed[date{0, d.month, d.day}] {
+		return true
+	}
+	for _, rule := range c.rules {
+		if m, day := rule(d.year); m == d.month && day == d.day {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay reports whether the date of t is neither a weekend day nor a holiday.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	return !c.weekend[t.Weekday()] && !c.IsHoliday(t)
+}
+
+/**
+ * Counts the business days in [start, end), by date. Each date is read from the wall clock of
+ * its own timestamp, so the sign follows the dates rather than the instants: a negative count
+ * means the date of end is before the date of start.
+ */
+func (c *Calendar) BusinessDaysBetween(start, end time.Time) int {
+	first, last := dateOf(start), dateOf(end)
+	if before(last, first) {
+		return -c.BusinessDaysBetween(end, start)
+	}
+	count := 0
+	for day := time.Date(first.year, first.month, first.day, 12, 0, 0, 0, time.UTC); before(dateOf(day), last); day = day.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(day) {
+			count++
+		}
+	}
+	return count
+}
+
+// Floor returns midnight of the last business day at or before t.
+func (c *Calendar) Floor(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	for i := 0; i < maxSearchDays && !c.IsBusinessDay(day); i++ {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// Shift moves t by n business days, keeping its clock time.
+func (c *Calendar) Shift(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		next := t.AddDate(0, 0, step)
+		for i := 0; i < maxSearchDays && !c.IsBusinessDay(next); i++ {
+			next = next.AddDate(0, 0, step)
+		}
+		t = next
+	}
+	return t
+}
+
+// String returns the name of the calendar.
+func (c *Calendar) String() string {
+	return c.name
+}
+
+/**
+ * Keeps the points that fall on business days.
+ */
+func (c *Calendar) Filter(ts timeseriesgo.TimeSeries) timeseriesgo.TimeSeries {
+	return ts.Filter(func(dp timeseriesgo.DataPoint) bool {
+		return c.IsBusinessDay(dp.Timestamp)
+	})
+}
+
+var _ timeseriesgo.Frequency = (*Calendar)(nil)
@@ -0,0 +1,139 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	timeseriesgo "github.com/wenta/timeseries-go"
+	"github.com/wenta/timeseries-go/generator"
+)
+
+func usCalendar() *Calendar {
+	return New("US").
+		AddFixedHoliday(time.July, 4).
+		AddFixedHoliday(time.December, 25).
+		AddRule(NthWeekday(time.May, time.Monday, -1)).
+		AddRule(NthWeekday(time.November, time.Thursday, 4))
+}
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHolidays(t *testing.T) {
+	c := usCalendar().AddClosure(day(2024, 1, 9))
+	cases := []struct {
+		t    time.Time
+		want bool
+	}{
+		{day(2024, 7, 4), true},
+		{day(2024, 5, 27), true},  // Memorial Day
+		{day(2024, 11, 28), true}, // Thanksgiving
+		{day(2024, 1, 9), true},   // closure
+		{day(2025, 1, 9), false},  // closures do not recur
+		{day(2024, 7, 5), false},
+	}
+	for _, c2 := range cases {
+		if got := c.IsHoliday(c2.t); got != c2.want {
+			t.Errorf("IsHoliday(%v) = %v, want %v", c2.t, got, c2.want)
+		}
+	}
+	if c.IsBusinessDay(day(2024, 7, 6)) {
+		t.Errorf("Saturday must not be a business day")
+	}
+}
+
+func TestEasterOffset(t *testing.T) {
+	cases := map[int]time.Time{
+		2024: day(2024, 3, 31),
+		2025: day(2025, 4, 20),
+		2000: day(2000, 4, 23),
+	}
+	for year, want := range cases {
+		if m, d := EasterOffset(0)(year); m != want.Month() || d != want.Day() {
+			t.Errorf("Easter %d: expected %v, got %v %d", year, want, m, d)
+		}
+	}
+	if m, d := EasterOffset(-2)(2024); m != time.March || d != 29 {
+		t.Errorf("expected Good Friday 2024 on March 29th, got %v %d", m, d)
+	}
+	if m, d := EasterOffset(1)(2024); m != time.April || d != 1 {
+		t.Errorf("expected Easter Monday 2024 on April 1st, got %v %d", m, d)
+	}
+}
+
+func TestCustomWeekend(t *testing.T) {
+	c := New("gulf", time.Friday, time.Saturday)
+	if c.IsBusinessDay(day(2024, 6, 7)) || !c.IsBusinessDay(day(2024, 6, 9)) {
+		t.Errorf("expected Friday closed and Sunday open")
+	}
+}
+
+func TestBusinessDaysBetweenAndShift(t *testing.T) {
+	c := usCalendar()
+	// July 2024: 23 weekdays, minus Independence Day.
+	if n := c.BusinessDaysBetween(day(2024, 7, 1), day(2024, 8, 1)); n != 22 {
+		t.Errorf("expected 22 business days in July 2024, got %d", n)
+	}
+	if n := c.BusinessDaysBetween(day(2024, 8, 1), day(2024, 7, 1)); n != -22 {
+		t.Errorf("expected -22 for a reversed range, got %d", n)
+	}
+	if got := c.Shift(day(2024, 7, 3), 1); !got.Equal(day(2024, 7, 5)) {
+		t.Errorf("expected the day after July 3rd to be July 5th, got %v", got)
+	}
+	if got := c.Shift(day(2024, 7, 8), -2); !got.Equal(day(2024, 7, 3)) {
+		t.Errorf("expected two business days before July 8th to be July 3rd, got %v", got)
+	}
+	if got := c.Floor(time.Date(2024, 7, 7, 15, 0, 0, 0, time.UTC)); !got.Equal(day(2024, 7, 5)) {
+		t.Errorf("expected Sunday to floor to Friday, got %v", got)
+	}
+}
+
+func TestBusinessDaysBetweenMixedLocations(t *testing.T) {
+	c := usCalendar()
+	sydney := time.FixedZone("AEST", 10*60*60)
+	// Tuesday 01:00 in Sydney is the Monday 15:00 UTC, before the Monday 20:00 UTC end,
+	// but the dates run from Tuesday back to Monday.
+	tue := time.Date(2024, 6, 11, 1, 0, 0, 0, sydney)
+	mon := time.Date(2024, 6, 10, 20, 0, 0, 0, time.UTC)
+	if n := c.BusinessDaysBetween(tue, mon); n != -1 {
+		t.Errorf("expected -1 from Tuesday back to Monday, got %d", n)
+	}
+	if n := c.BusinessDaysBetween(mon, tue); n != 1 {
+		t.Errorf("expected 1 from Monday to Tuesday, got %d", n)
+	}
+}
+
+func TestCalendarAsFrequency(t *testing.T) {
+	c := usCalendar()
+	index := generator.MakeSeriesIndexWithFrequency(day(2024, 7, 3), c, 3)
+	expected := []time.Time{day(2024, 7, 3), day(2024, 7, 5), day(2024, 7, 8)}
+	for i := range expected {
+		if !index[i].Equal(expected[i]) {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], index[i])
+		}
+	}
+
+	ts := timeseriesgo.Empty()
+	for d := 3; d <= 8; d++ {
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: day(2024, 7, d), Value: 1})
+	}
+	if business := c.Filter(ts); business.Length() != 3 {
+		t.Errorf("expected 3 business-day points, got %d", business.Length())
+	}
+
+	// Weekend and holiday points roll into the previous business day.
+	grouped := ts.GroupByTime(c.Floor, func(dps []timeseriesgo.DataPoint) float64 { return float64(len(dps)) })
+	perDay := ts.Downsample(c, timeseriesgo.AggregateCount, timeseriesgo.ResampleOptions{})
+	for _, got := range []timeseriesgo.TimeSeries{grouped, perDay} {
+		want := []float64{2, 3, 1}
+		if got.Length() != len(want) {
+			t.Fatalf("expected %d groups, got %d", len(want), got.Length())
+		}
+		for i, v := range got.Values() {
+			if v != want[i] {
+				t.Errorf("idx %d expected %v, got %v", i, want[i], v)
+			}
+		}
+	}
+}
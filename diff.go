@@ -0,0 +1,224 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+/**
+ * Differences the series order times at lag: every pass replaces each value with its
+ * difference from the value lag points earlier and drops the first lag points.
+ * Diff(1, 1) is Differentiate; Diff(7, 1) removes a weekly season from daily data.
+ *
+ * @param lag The distance in points, at least 1.
+ * @param order The number of passes; 0 returns a copy.
+ *
+ * @return The differenced series, lag*order points shorter (empty if too short), or an error for a bad lag or order.
+ */
+func (ts *TimeSeries) Diff(lag, order int) (TimeSeries, error) {
+	if lag < 1 {
+		return Empty(), errors.New("lag must be at least 1")
+	}
+	if order < 0 {
+		return Empty(), errors.New("order must not be negative")
+	}
+	times, values := ts.times, ts.values
+	for ; order > 0; order-- {
+		if len(values) <= lag {
+			return ts.derive(), nil
+		}
+		next := make([]float64, len(values)-lag)
+		for i := range next {
+			next[i] = values[i+lag] - values[i]
+		}
+		times, values = times[lag:], next
+	}
+	res := ts.derive()
+	for i, t := range times {
+		res.appendRaw(t, values[i])
+	}
+	return res, nil
+}
+
+/**
+ * Inverts Diff: treats ts as differences (at the same lag and order) of the values that
+ * follow history and rebuilds them. With history being the first lag*order points of a series,
+ * this restores the rest of it from its Diff; with history being the observed data, it turns
+ * a forecast of differences into a forecast of levels.
+ *
+ * @param history The values just before ts, at least lag*order points; only the last lag*order are used.
+ * @param lag The lag given to Diff.
+ * @param order The order given to Diff.
+ *
+ * @return The rebuilt levels at the timestamps of ts, or an error if history is too short or lag or order are bad.
+ */
+func (ts *TimeSeries) InvertDiff(history TimeSeries, lag, order int) (TimeSeries, error) {
+	if lag < 1 {
+		return Empty(), errors.New("lag must be at least 1")
+	}
+	if order < 0 {
+		return Empty(), errors.New("order must not be negative")
+	}
+	if history.Length() < lag*order {
+		return Empty(), errors.New("history must hold at least lag*order points")
+	}
+	// levels[k] holds the tail of history differenced k times; each needs lag values to seed level k.
+	levels := make([][]float64, order)
+	tail := history.values[len(history.values)-lag*order:]
+	for k := range levels {
+		levels[k] = tail
+		next := make([]float64, len(tail)-lag)
+		for i := range next {
+			next[i] = tail[i+lag] - tail[i]
+		}
+		tail = next
+	}
+	values := ts.Values()
+	for k := order - 1; k >= 0; k-- {
+		seed := levels[k][len(levels[k])-lag:]
+		for i := range values {
+			if i < lag {
+				values[i] += seed[i]
+			} else {
+				values[i] += values[i-lag]
+			}
+		}
+	}
+	res := ts.derive()
+	for i, t := range ts.times {
+		res.appendRaw(t, values[i])
+	}
+	return res, nil
+}
+
+// counterTotals returns the counter values with resets compensated: whenever a value drops
+// below the previous one, the counter is taken to have restarted from zero and the value
+// before the drop is carried over. NaN values stay NaN and do not count as resets.
+func (ts *TimeSeries) counterTotals() []float64 {
+	res := make([]float64, len(ts.values))
+	offset, previous := 0.0, math.NaN()
+	for i, v := range ts.values {
+		if math.IsNaN(v) {
+			res[i] = v
+			continue
+		}
+		if v < previous {
+			offset += previous
+		}
+		previous = v
+		res[i] = v + offset
+	}
+	return res
+}
+
+// counterWindows calls f for every point with the reset-compensated increase between the
+// first and last non-NaN values of its window (t - window, t] and the seconds between them.
+// f is not called for windows with fewer than two values; those outputs are NaN.
+func (ts *TimeSeries) counterWindows(window time.Duration, f func(increase, seconds float64) float64) TimeSeries {
+	totals := ts.counterTotals()
+	n := len(totals)
+	// next[i] is the first non-NaN index at or after i, prev[i+1] the last one at or before i.
+	next, prev := make([]int, n+1), make([]int, n+1)
+	next[n] = n
+	for i := n - 1; i >= 0; i-- {
+		next[i] = next[i+1]
+		if !math.IsNaN(totals[i]) {
+			next[i] = i
+		}
+	}
+	prev[0] = -1
+	for i := range totals {
+		prev[i+1] = prev[i]
+		if !math.IsNaN(totals[i]) {
+			prev[i+1] = i
+		}
+	}
+	los, his := TimeWindow(window).bounds(ts.times, ts.core().Location())
+	res := ts.derive()
+	for i, t := range ts.times {
+		out := math.NaN()
+		if first, last := next[los[i]], prev[his[i]]; first < last {
+			out = f(totals[last]-totals[first], time.Duration(ts.times[last]-ts.times[first]).Seconds())
+		}
+		res.appendRaw(t, out)
+	}
+	return res
+}
+
+/**
+ * Returns how much a monotonic counter grew over the trailing window (t - window, t] at
+ * every point, compensating for resets (a drop means the counter restarted from zero).
+ * Unlike Prometheus there is no extrapolation to the window edges: the increase is measured
+ * between the first and last samples in the window. Windows with fewer than two samples give NaN.
+ */
+func (ts *TimeSeries) Increase(window time.Duration) TimeSeries {
+	return ts.counterWindows(window, func(increase, _ float64) float64 { return increase })
+}
+
+/**
+ * Returns the per-second rate of a monotonic counter over the trailing window: Increase divided
+ * by the seconds between the first and last samples in the window.
+ */
+func (ts *TimeSeries) Rate(window time.Duration) TimeSeries {
+	return ts.counterWindows(window, func(increase, seconds float64) float64 {
+		if seconds == 0 {
+			return math.NaN()
+		}
+		return increase / seconds
+	})
+}
+
+/**
+ * Returns the per-second derivative of a gauge over the trailing window (t - window, t],
+ * estimated by least-squares regression like Prometheus deriv. Counter resets are not compensated.
+ * Windows with fewer than two samples, or all at one instant, give NaN.
+ */
+func (ts *TimeSeries) Deriv(window time.Duration) TimeSeries {
+	return ts.Rolling(TimeWindow(window)).run(&slopeAcc{times: ts.times})
+}
+
+// slopeAcc keeps running means and co-moments of (seconds, value) pairs, updated like varAcc.
+// Seconds are counted from the first point of the series to keep them small.
+type slopeAcc struct {
+	times  []int64
+	n      int
+	mx, my float64
+	cxx    float64
+	cxy    float64
+}
+
+func (a *slopeAcc) x(i int) float64 {
+	return time.Duration(a.times[i] - a.times[0]).Seconds()
+}
+
+func (a *slopeAcc) add(i int, y float64) {
+	x := a.x(i)
+	a.n++
+	dx := x - a.mx
+	a.mx += dx / float64(a.n)
+	a.my += (y - a.my) / float64(a.n)
+	a.cxx += dx * (x - a.mx)
+	a.cxy += dx * (y - a.my)
+}
+
+func (a *slopeAcc) remove(i int, y float64) {
+	if a.n == 1 {
+		a.n, a.mx, a.my, a.cxx, a.cxy = 0, 0, 0, 0, 0
+		return
+	}
+	x := a.x(i)
+	a.n--
+	dx, dy := x-a.mx, y-a.my
+	a.mx -= dx / float64(a.n)
+	a.my -= dy / float64(a.n)
+	a.cxx -= (x - a.mx) * dx
+	a.cxy -= (x - a.mx) * dy
+}
+
+func (a *slopeAcc) value() float64 {
+	if a.n < 2 || a.cxx <= 0 {
+		return math.NaN()
+	}
+	return a.cxy / a.cxx
+}
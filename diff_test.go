@@ -0,0 +1,74 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4, 5}, []float64{1, 4, 9, 16, 25, 36})
+
+	first, _ := ts.Diff(1, 1)
+	assertSeriesValues(t, "first", first, []float64{3, 5, 7, 9, 11})
+	second, _ := ts.Diff(1, 2)
+	assertSeriesValues(t, "second", second, []float64{2, 2, 2, 2})
+	if got := second.Timestamps()[0]; !got.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("expected the first difference at 00:02, got %v", got)
+	}
+	seasonal, _ := ts.Diff(2, 1)
+	assertSeriesValues(t, "seasonal", seasonal, []float64{8, 12, 16, 20})
+	tooShort, _ := ts.Diff(3, 2)
+	if !tooShort.IsEmpty() {
+		t.Errorf("expected an empty series, got %d points", tooShort.Length())
+	}
+	if _, err := ts.Diff(0, 1); err == nil {
+		t.Error("expected an error for lag 0")
+	}
+}
+
+func TestInvertDiff(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4, 5, 6, 7}, []float64{5, 3, 8, 1, 9, 4, 7, 2})
+	for _, c := range []struct{ lag, order int }{{1, 1}, {1, 2}, {2, 1}, {3, 2}} {
+		diffed, _ := ts.Diff(c.lag, c.order)
+		head := ts.Slice(ts.Timestamps()[0], ts.Timestamps()[c.lag*c.order])
+		restored, err := diffed.InvertDiff(head, c.lag, c.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSeriesValues(t, "restored", restored, ts.Values()[c.lag*c.order:])
+	}
+
+	// Continue a linear trend: second differences of zero extend the last slope.
+	history := minuteSeries(base, []int{0, 1, 2}, []float64{1, 3, 5})
+	forecast := minuteSeries(base, []int{3, 4}, []float64{0, 0})
+	levels, _ := forecast.InvertDiff(history, 1, 2)
+	assertSeriesValues(t, "forecast", levels, []float64{7, 9})
+	if _, err := forecast.InvertDiff(history, 2, 2); err == nil {
+		t.Error("expected an error for a short history")
+	}
+}
+
+func TestCounterRateAndIncrease(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	// The counter restarts between 00:02 and 00:03.
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{100, 160, 220, 30, 90})
+	assertSeriesValues(t, "increase", ts.Increase(2*time.Minute+time.Second), []float64{nan, 60, 120, 90, 90})
+	assertSeriesValues(t, "rate", ts.Rate(2*time.Minute+time.Second), []float64{nan, 1, 1, 0.75, 0.75})
+
+	gaps := minuteSeries(base, []int{0, 1, 2}, []float64{10, nan, 70})
+	assertSeriesValues(t, "nan skipped", gaps.Increase(time.Hour), []float64{nan, nan, 60})
+}
+
+func TestDeriv(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 5}, []float64{0, 120, 240, 180, 60})
+	// Slopes per second of the least-squares lines through each 3-minute window.
+	want := []float64{nan, 2, 2, 0.5, -1}
+	got := ts.Deriv(3 * time.Minute)
+	assertSeriesValues(t, "deriv", got, want)
+}
@@ -0,0 +1,142 @@
+package timeseriesgo
+
+import "math"
+
+// Expanding computes statistics over all points from the start of the series (or the last
+// reset) up to and including each point.
+type Expanding struct {
+	ts         *TimeSeries
+	minPeriods int
+	reset      func(DataPoint) bool
+}
+
+/**
+ * Returns the expanding view of the series. The series must be sorted.
+ * Every statistic is computed in one pass; NaN values are skipped.
+ */
+func (ts *TimeSeries) Expanding() Expanding {
+	return Expanding{ts: ts}
+}
+
+/**
+ * Returns the view with a minimum number of non-NaN values; outputs with fewer are NaN.
+ * The default is 1.
+ */
+func (e Expanding) WithMinPeriods(n int) Expanding {
+	e.minPeriods = n
+	return e
+}
+
+/**
+ * Returns the view that starts over at every point for which pred is true:
+ * that point is the first one of the new window.
+ * Example: ResetWhen(func(dp DataPoint) bool { return dp.Timestamp.Hour() == 0 }) for daily totals,
+ * assuming a point at midnight.
+ */
+func (e Expanding) ResetWhen(pred func(DataPoint) bool) Expanding {
+	e.reset = pred
+	return e
+}
+
+// run feeds every point to an accumulator; each segment between resets gets a new one
+// from newAcc, which is told the segment's index range [lo, hi).
+func (e Expanding) run(newAcc func(lo, hi int) accumulator) TimeSeries {
+	ts := e.ts
+	res := ts.derive()
+	core := ts.core()
+	minPeriods := max(e.minPeriods, 1)
+	starts := []int{0}
+	if e.reset != nil {
+		for i := 1; i < len(ts.times); i++ {
+			if e.reset(core.pointAt(i)) {
+				starts = append(starts, i)
+			}
+		}
+	}
+	starts = append(starts, len(ts.times))
+	for k := 0; k+1 < len(starts); k++ {
+		lo, hi := starts[k], starts[k+1]
+		acc, valid := newAcc(lo, hi), 0
+		for i := lo; i < hi; i++ {
+			if v := ts.values[i]; !math.IsNaN(v) {
+				acc.add(i, v)
+				valid++
+			}
+			out := math.NaN()
+			if valid >= minPeriods {
+				out = acc.value()
+			}
+			res.appendRaw(ts.times[i], out)
+		}
+	}
+	return res
+}
+
+// Sum returns the cumulative sum.
+func (e Expanding) Sum() TimeSeries {
+	return e.run(func(int, int) accumulator { return &sumAcc{} })
+}
+
+// Mean returns the running mean.
+func (e Expanding) Mean() TimeSeries {
+	return e.run(func(int, int) accumulator { return &meanAcc{} })
+}
+
+// Var returns the running sample variance; NaN until there are two values.
+func (e Expanding) Var() TimeSeries {
+	return e.run(func(int, int) accumulator { return &varAcc{} })
+}
+
+// Std returns the running sample standard deviation.
+func (e Expanding) Std() TimeSeries {
+	return e.run(func(int, int) accumulator { return &varAcc{std: true} })
+}
+
+// Count returns the number of non-NaN values so far.
+func (e Expanding) Count() TimeSeries {
+	return e.run(func(int, int) accumulator { return &countAcc{} })
+}
+
+// Min returns the running minimum.
+func (e Expanding) Min() TimeSeries {
+	return e.run(func(int, int) accumulator { return &runningExtremeAcc{less: func(a, b float64) bool { return a < b }} })
+}
+
+// Max returns the running maximum, e.g. the peak that a drawdown is measured from.
+func (e Expanding) Max() TimeSeries {
+	return e.run(func(int, int) accumulator { return &runningExtremeAcc{less: func(a, b float64) bool { return a > b }} })
+}
+
+// Median returns the running median.
+func (e Expanding) Median() TimeSeries {
+	return e.Quantile(0.5)
+}
+
+/**
+ * Returns the running q-quantile (0 <= q <= 1), interpolated like Rolling.Quantile.
+ * Costs O(log n) per point. q outside [0, 1] gives NaN.
+ */
+func (e Expanding) Quantile(q float64) TimeSeries {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return e.ts.MapValues(func(float64) float64 { return math.NaN() })
+	}
+	return e.run(func(int, int) accumulator {
+		return &quantileAcc{stats: newOrderStats(), q: q}
+	})
+}
+
+// runningExtremeAcc keeps the extreme of values that are never removed.
+type runningExtremeAcc struct {
+	best float64
+	seen bool
+	less func(a, b float64) bool
+}
+
+func (a *runningExtremeAcc) add(_ int, v float64) {
+	if !a.seen || a.less(v, a.best) {
+		a.best, a.seen = v, true
+	}
+}
+
+func (a *runningExtremeAcc) remove(int, float64) {}
+func (a *runningExtremeAcc) value() float64      { return a.best }
@@ -0,0 +1,36 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExpandingStatistics(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	nan := math.NaN()
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{3, 1, nan, 4, 2})
+	e := ts.Expanding()
+	assertSeriesValues(t, "sum", e.Sum(), []float64{3, 4, 4, 8, 10})
+	assertSeriesValues(t, "mean", e.Mean(), []float64{3, 2, 2, 8.0 / 3, 2.5})
+	assertSeriesValues(t, "var", e.Var(), []float64{nan, 2, 2, 7.0 / 3, 5.0 / 3})
+	assertSeriesValues(t, "count", e.Count(), []float64{1, 2, 2, 3, 4})
+	assertSeriesValues(t, "min", e.Min(), []float64{3, 1, 1, 1, 1})
+	assertSeriesValues(t, "max", e.Max(), []float64{3, 3, 3, 4, 4})
+	assertSeriesValues(t, "median", e.Median(), []float64{3, 2, 2, 3, 2.5})
+	assertSeriesValues(t, "min periods", e.WithMinPeriods(3).Sum(), []float64{nan, nan, nan, 8, 10})
+}
+
+func TestExpandingResetWhen(t *testing.T) {
+	base := time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC)
+	ts := Empty()
+	for i, v := range []float64{1, 2, 3, 4, 5} {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Hour), v})
+	}
+	midnight := func(dp DataPoint) bool { return dp.Timestamp.Hour() == 0 }
+	e := ts.Expanding().ResetWhen(midnight)
+	assertSeriesValues(t, "sum", e.Sum(), []float64{1, 3, 3, 7, 12})
+	assertSeriesValues(t, "max", e.Max(), []float64{1, 2, 3, 4, 5})
+	assertSeriesValues(t, "quantile", e.Quantile(1), []float64{1, 2, 3, 4, 5})
+	assertSeriesValues(t, "count", e.Count(), []float64{1, 2, 1, 2, 3})
+}
@@ -8,6 +8,7 @@ import (
 
 /**
  * Implements a naive forecasting method that uses the last observed value to forecast future values.
+ * Forecast timestamps follow the frequency inferred from ts (see TimeSeries.InferFrequency).
  *
  * @param ts The TimeSeries to forecast.
  * @param forecastHorizon The number of future points to forecast.
@@ -17,28 +18,37 @@ func Naive(ts timeseriesgo.TimeSeries, forecastHorizon int) timeseriesgo.TimeSer
 	if ts.IsEmpty() || forecastHorizon <= 0 {
 		return timeseriesgo.Empty()
 	}
-	lastPoint, err := ts.Last()
+	inferred, err := ts.InferFrequency()
 	if err != nil {
-		return timeseriesgo.Empty()
-	}
-	forecastSeries := timeseriesgo.Empty()
-	points := ts.DataPoints()
-	if len(points) < 2 {
+		forecastSeries := timeseriesgo.Empty()
+		forecastSeries.SetMetadata(ts.Metadata())
 		return forecastSeries
 	}
-	interval := points[1].Timestamp.Sub(points[0].Timestamp)
-	for i := 1; i <= forecastHorizon; i++ {
-		forecastTime := lastPoint.Timestamp.Add(time.Duration(i) * interval)
-		forecastSeries.AddPoint(timeseriesgo.DataPoint{
-			Timestamp: forecastTime,
-			Value:     lastPoint.Value,
-		})
+	return NaiveWithFrequency(ts, forecastHorizon, inferred.Frequency)
+}
+
+/**
+ * Naive forecast on the grid of a Frequency, e.g. MonthEnd(1) for monthly closing values.
+ *
+ * @param ts The TimeSeries to forecast.
+ * @param forecastHorizon The number of future points to forecast.
+ * @param freq The step between forecasted points, applied from the last observed timestamp.
+ * @return A TimeSeries containing the forecasted points.
+ */
+func NaiveWithFrequency(ts timeseriesgo.TimeSeries, forecastHorizon int, freq timeseriesgo.Frequency) timeseriesgo.TimeSeries {
+	if ts.IsEmpty() || forecastHorizon <= 0 {
+		return timeseriesgo.Empty()
 	}
-	return forecastSeries
+	lastPoint, err := ts.Last()
+	if err != nil {
+		return timeseriesgo.Empty()
+	}
+	return constantForecast(ts, lastPoint.Timestamp, lastPoint.Value, forecastHorizon, freq)
 }
 
 /**
  * Implements Simple Exponential Smoothing (SES) forecasting method.
+ * Forecast timestamps follow the frequency inferred from ts (see TimeSeries.InferFrequency).
  *
  * @param ts The TimeSeries to forecast. Expected that ts is already sorted by timestamp
  * @param alpha The smoothing factor (0 < alpha <= 1).
@@ -49,10 +59,27 @@ func SimpleExponentialSmoothing(ts timeseriesgo.TimeSeries, alpha float64, forec
 	if ts.IsEmpty() || forecastHorizon <= 0 || alpha < 0 || alpha > 1 {
 		return timeseriesgo.Empty()
 	}
-	points := ts.DataPoints()
-	if len(points) < 2 {
+	inferred, err := ts.InferFrequency()
+	if err != nil {
+		return timeseriesgo.Empty()
+	}
+	return SimpleExponentialSmoothingWithFrequency(ts, alpha, forecastHorizon, inferred.Frequency)
+}
+
+/**
+ * Simple Exponential Smoothing on the grid of a Frequency.
+ *
+ * @param ts The TimeSeries to forecast. Expected that ts is already sorted by timestamp
+ * @param alpha The smoothing factor (0 < alpha <= 1).
+ * @param forecastHorizon The number of future points to forecast.
+ * @param freq The step between forecasted points, applied from the last observed timestamp.
+ * @return A TimeSeries containing the forecasted points.
+ */
+func SimpleExponentialSmoothingWithFrequency(ts timeseriesgo.TimeSeries, alpha float64, forecastHorizon int, freq timeseriesgo.Frequency) timeseriesgo.TimeSeries {
+	if ts.IsEmpty() || forecastHorizon <= 0 || alpha < 0 || alpha > 1 {
 		return timeseriesgo.Empty()
 	}
+	points := ts.DataPoints()
 
 	// Initialize the smoothed value with the first data point's value.
 	smoothedValue := points[0].Value
@@ -62,15 +89,19 @@ func SimpleExponentialSmoothing(ts timeseriesgo.TimeSeries, alpha float64, forec
 		smoothedValue = alpha*point.Value + (1-alpha)*smoothedValue
 	}
 
-	// Generate forecasted points.
-	forecastSeries := timeseriesgo.Empty()
 	lastPoint, _ := ts.Last()
-	interval := points[1].Timestamp.Sub(points[0].Timestamp)
+	return constantForecast(ts, lastPoint.Timestamp, smoothedValue, forecastHorizon, freq)
+}
+
+// constantForecast emits forecastHorizon points of value after last, stepping by freq,
+// carrying the metadata of ts.
+func constantForecast(ts timeseriesgo.TimeSeries, last time.Time, value float64, forecastHorizon int, freq timeseriesgo.Frequency) timeseriesgo.TimeSeries {
+	forecastSeries := timeseriesgo.Empty()
+	forecastSeries.SetMetadata(ts.Metadata())
 	for i := 1; i <= forecastHorizon; i++ {
-		forecastTime := lastPoint.Timestamp.Add(time.Duration(i) * interval)
 		forecastSeries.AddPoint(timeseriesgo.DataPoint{
-			Timestamp: forecastTime,
-			Value:     smoothedValue,
+			Timestamp: freq.Shift(last, i),
+			Value:     value,
 		})
 	}
 	return forecastSeries
@@ -125,3 +125,41 @@ func TestSimpleExponentialSmoothingStatsmodelsOilData(t *testing.T) {
 		}
 	}
 }
+
+func TestNaiveWithFrequency(t *testing.T) {
+	ts := timeseriesgo.Empty()
+	ts.AddPoint(timeseriesgo.DataPoint{Timestamp: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Value: 7})
+	forecast := NaiveWithFrequency(ts, 2, timeseriesgo.MonthEnd(1))
+	expected := []time.Time{
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if forecast.Length() != len(expected) {
+		t.Fatalf("Expected forecast length %d, got %d", len(expected), forecast.Length())
+	}
+	for i, dp := range forecast.DataPoints() {
+		if !dp.Timestamp.Equal(expected[i]) || dp.Value != 7 {
+			t.Errorf("At index %d: expected 7 at %v, got %v at %v", i, expected[i], dp.Value, dp.Timestamp)
+		}
+	}
+}
+
+func TestNaiveUsesInferredCalendarFrequency(t *testing.T) {
+	ts := timeseriesgo.Empty()
+	for m := time.January; m <= time.March; m++ {
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC), Value: float64(m)})
+	}
+	forecast := Naive(ts, 2)
+	expected := []time.Time{
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if forecast.Length() != len(expected) {
+		t.Fatalf("Expected %d forecast points, got %d", len(expected), forecast.Length())
+	}
+	for i, dp := range forecast.DataPoints() {
+		if !dp.Timestamp.Equal(expected[i]) {
+			t.Errorf("At index %d: expected timestamp %v, got %v", i, expected[i], dp.Timestamp)
+		}
+	}
+}
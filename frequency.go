@@ -0,0 +1,449 @@
+package timeseriesgo
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency describes a grid of timestamps. Fixed-duration frequencies (Every) step by
+// exact elapsed time; calendar frequencies step by days, weeks, months, quarters, years
+// or business days in the location of the timestamp they are given, so "1 month" and
+// "every Monday" keep their meaning across month lengths and DST changes.
+type Frequency interface {
+	// Floor returns the last grid timestamp at or before t.
+	Floor(t time.Time) time.Time
+	// Shift moves t by n periods (n may be negative). A grid timestamp stays on the grid.
+	Shift(t time.Time, n int) time.Time
+	// String names the frequency, in the notation accepted by ParseFrequency where one exists.
+	String() string
+}
+
+/**
+ * Returns the fixed-duration frequency d. It is not anchored to any origin:
+ * Floor returns its argument, so grids start at the first timestamp of the data.
+ */
+func Every(d time.Duration) Frequency {
+	return fixedFrequency(d)
+}
+
+type fixedFrequency time.Duration
+
+func (f fixedFrequency) Floor(t time.Time) time.Time {
+	return t
+}
+
+func (f fixedFrequency) Shift(t time.Time, n int) time.Time {
+	return t.Add(time.Duration(n) * time.Duration(f))
+}
+
+func (f fixedFrequency) String() string {
+	d := time.Duration(f)
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "h"}, {time.Minute, "min"}, {time.Second, "s"},
+		{time.Millisecond, "ms"}, {time.Microsecond, "us"},
+	}
+	for _, u := range units {
+		if d != 0 && d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
+
+/**
+ * Returns the fixed-duration frequency d anchored at origin: grid timestamps are
+ * origin + k*d for any integer k, e.g. quarter hours when origin is a midnight.
+ */
+func EveryFrom(d time.Duration, origin time.Time) Frequency {
+	return anchoredFrequency{step: d, origin: origin.UnixNano()}
+}
+
+type anchoredFrequency struct {
+	step   time.Duration
+	origin int64
+}
+
+func (f anchoredFrequency) Floor(t time.Time) time.Time {
+	if f.step <= 0 {
+		return t
+	}
+	step := int64(f.step)
+	k := (t.UnixNano() - f.origin) / step
+	if (t.UnixNano()-f.origin)%step < 0 {
+		k--
+	}
+	return time.Unix(0, f.origin+k*step).In(t.Location())
+}
+
+func (f anchoredFrequency) Shift(t time.Time, n int) time.Time {
+	return t.Add(time.Duration(n) * f.step)
+}
+
+func (f anchoredFrequency) String() string {
+	return fixedFrequency(f.step).String()
+}
+
+// calendarUnit is the step of a calendar frequency.
+type calendarUnit int
+
+const (
+	unitDay calendarUnit = iota
+	unitWeek
+	unitMonth
+	unitQuarter
+	unitYear
+	unitBusinessDay
+)
+
+// calendarFrequency steps by whole calendar units. Grid timestamps are at midnight;
+// end-anchored frequencies sit on the last day of their period. A multiple of n periods
+// keeps every n-th period of the single-period grid, counted from a fixed epoch: days,
+// weeks and business days from January 1970, months, quarters and years from year 0. So
+// MonthStart(2) ticks in January, March, May and so on, and Days(2) on the same days
+// whatever the data. phase moves the kept periods, e.g. to February, April and so on.
+type calendarFrequency struct {
+	unit    calendarUnit
+	n       int
+	end     bool
+	weekday time.Weekday
+	phase   int
+}
+
+func newCalendar(unit calendarUnit, n int, end bool) calendarFrequency {
+	if n < 1 {
+		n = 1
+	}
+	return calendarFrequency{unit: unit, n: n, end: end}
+}
+
+// Days returns a frequency of n calendar days with ticks at midnight.
+func Days(n int) Frequency {
+	return newCalendar(unitDay, n, false)
+}
+
+// Weeks returns a frequency of n weeks with ticks at midnight on the given weekday.
+func Weeks(n int, weekday time.Weekday) Frequency {
+	f := newCalendar(unitWeek, n, false)
+	f.weekday = weekday
+	return f
+}
+
+// MonthStart returns a frequency of n months with ticks on the first day of the month.
+func MonthStart(n int) Frequency {
+	return newCalendar(unitMonth, n, false)
+}
+
+// MonthEnd returns a frequency of n months with ticks on the last day of the month.
+func MonthEnd(n int) Frequency {
+	return newCalendar(unitMonth, n, true)
+}
+
+// QuarterStart returns a frequency of n quarters with ticks on January, April, July and October 1st.
+func QuarterStart(n int) Frequency {
+	return newCalendar(unitQuarter, n, false)
+}
+
+// QuarterEnd returns a frequency of n quarters with ticks on the last day of March, June, September and December.
+func QuarterEnd(n int) Frequency {
+	return newCalendar(unitQuarter, n, true)
+}
+
+// YearStart returns a frequency of n years with ticks on January 1st.
+func YearStart(n int) Frequency {
+	return newCalendar(unitYear, n, false)
+}
+
+// YearEnd returns a frequency of n years with ticks on December 31st.
+func YearEnd(n int) Frequency {
+	return newCalendar(unitYear, n, true)
+}
+
+// BusinessDays returns a frequency of n weekdays (Monday to Friday) with ticks at midnight.
+func BusinessDays(n int) Frequency {
+	return newCalendar(unitBusinessDay, n, false)
+}
+
+func (f calendarFrequency) Floor(t time.Time) time.Time {
+	base := f.floorPeriod(t)
+	if f.n == 1 {
+		return base
+	}
+	single := f
+	single.n = 1
+	return single.Shift(base, -floorMod(f.period(base)-f.phase, f.n))
+}
+
+// floorPeriod returns the last single-period grid timestamp at or before t.
+func (f calendarFrequency) floorPeriod(t time.Time) time.Time {
+	y, m, d := t.Date()
+	loc := t.Location()
+	switch f.unit {
+	case unitWeek:
+		back := (int(t.Weekday()) - int(f.weekday) + 7) % 7
+		return time.Date(y, m, d-back, 0, 0, 0, 0, loc)
+	case unitBusinessDay:
+		switch t.Weekday() {
+		case time.Saturday:
+			d--
+		case time.Sunday:
+			d -= 2
+		}
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	case unitMonth, unitQuarter, unitYear:
+		span := f.monthsPerPeriod()
+		// Months since the start of the year, rounded down to the period.
+		first := time.Month((int(m)-1)/span*span + 1)
+		if !f.end {
+			return time.Date(y, first, 1, 0, 0, 0, 0, loc)
+		}
+		tick := time.Date(y, first+time.Month(span), 0, 0, 0, 0, 0, loc)
+		if t.Before(tick) {
+			tick = time.Date(y, first, 0, 0, 0, 0, 0, loc)
+		}
+		return tick
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// period numbers the single-period grid timestamp t from the epoch of its unit.
+func (f calendarFrequency) period(t time.Time) int {
+	y, m, d := t.Date()
+	days := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+	switch f.unit {
+	case unitWeek:
+		return floorDiv(days, 7)
+	case unitBusinessDay:
+		// Monday, January 5th 1970 is business day 0.
+		days -= 4
+		return 5*floorDiv(days, 7) + floorMod(days, 7)
+	case unitMonth, unitQuarter, unitYear:
+		return floorDiv(12*y+int(m)-1, f.monthsPerPeriod())
+	}
+	return days
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
+
+func (f calendarFrequency) Shift(t time.Time, n int) time.Time {
+	switch f.unit {
+	case unitDay:
+		return t.AddDate(0, 0, n*f.n)
+	case unitWeek:
+		return t.AddDate(0, 0, 7*n*f.n)
+	case unitBusinessDay:
+		return addBusinessDays(t, n*f.n)
+	}
+	return addMonths(t, n*f.n*f.monthsPerPeriod(), f.end)
+}
+
+func (f calendarFrequency) monthsPerPeriod() int {
+	switch f.unit {
+	case unitQuarter:
+		return 3
+	case unitYear:
+		return 12
+	}
+	return 1
+}
+
+func (f calendarFrequency) String() string {
+	var name string
+	switch f.unit {
+	case unitDay:
+		name = "D"
+	case unitWeek:
+		name = "W-" + strings.ToUpper(f.weekday.String()[:3])
+	case unitBusinessDay:
+		name = "B"
+	case unitMonth:
+		name = "M"
+	case unitQuarter:
+		name = "Q"
+	case unitYear:
+		name = "Y"
+	}
+	if f.unit == unitMonth || f.unit == unitQuarter || f.unit == unitYear {
+		if f.end {
+			name += "E"
+		} else {
+			name += "S"
+		}
+	}
+	if f.n == 1 {
+		return name
+	}
+	return strconv.Itoa(f.n) + name
+}
+
+// addMonths moves t by months, clamping the day to the length of the target month
+// (January 31st plus one month is the last day of February). With end set, a timestamp
+// on the last day of its month moves to the last day of the target month.
+func addMonths(t time.Time, months int, end bool) time.Time {
+	y, m, d := t.Date()
+	hh, mm, ss := t.Clock()
+	target := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := daysIn(target.Year(), target.Month(), t.Location())
+	if d > last || (end && d == daysIn(y, m, t.Location())) {
+		d = last
+	}
+	return time.Date(target.Year(), target.Month(), d, hh, mm, ss, t.Nanosecond(), t.Location())
+}
+
+func daysIn(y int, m time.Month, loc *time.Location) int {
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+// addBusinessDays moves t by n weekdays, skipping Saturdays and Sundays. From a weekend
+// day, the first step lands on the next weekday in the direction of n.
+func addBusinessDays(t time.Time, n int) time.Time {
+	if n == 0 {
+		return t
+	}
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	// Roll a weekend day back against the direction of travel, onto the weekday that
+	// counts as step zero, so the week jump below cannot land on a weekend.
+	for wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday; wd = t.Weekday() {
+		t = t.AddDate(0, 0, -step)
+	}
+	// Whole weeks first, then the remaining days one at a time.
+	t = t.AddDate(0, 0, step*7*(n/5))
+	for n %= 5; n > 0; {
+		t = t.AddDate(0, 0, step)
+		if wd := t.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n--
+		}
+	}
+	return t
+}
+
+/**
+ * Returns the first grid timestamp at or after t.
+ */
+func Ceil(freq Frequency, t time.Time) time.Time {
+	floor := freq.Floor(t)
+	if floor.Equal(t) {
+		return floor
+	}
+	return freq.Shift(floor, 1)
+}
+
+/**
+ * Yields the grid timestamps of freq from start (rolled forward onto the grid) up to and including end.
+ * A frequency that does not move forward (e.g. Every(0)) yields nothing.
+ */
+func Ticks(freq Frequency, start, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if !advances(freq, start) {
+			return
+		}
+		for t := Ceil(freq, start); !t.After(end); t = freq.Shift(t, 1) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// advances reports whether freq moves t forward, guarding loops against zero or negative frequencies.
+func advances(freq Frequency, t time.Time) bool {
+	return freq != nil && freq.Shift(t, 1).After(t)
+}
+
+var fixedUnits = map[string]time.Duration{
+	"ns":  time.Nanosecond,
+	"us":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"S":   time.Second,
+	"min": time.Minute,
+	"T":   time.Minute,
+	"h":   time.Hour,
+	"H":   time.Hour,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"SUN": time.Sunday, "MON": time.Monday, "TUE": time.Tuesday, "WED": time.Wednesday,
+	"THU": time.Thursday, "FRI": time.Friday, "SAT": time.Saturday,
+}
+
+/**
+ * Parses a frequency string: an optional positive multiple followed by a unit.
+ *
+ * Fixed units: ns, us, ms, s, min (or T), h (or H). Calendar units: D (days),
+ * W or W-MON..W-SUN (weeks, W alone anchors on Sunday), B (business days),
+ * MS/ME (month start/end, M alone is month end), QS/QE (Q is quarter end) and
+ * YS/YE (Y is year end). Go duration strings such as "1h30m" are accepted too.
+ *
+ * @return The frequency, or an error for an unknown unit or a non-positive multiple.
+ */
+func ParseFrequency(s string) (Frequency, error) {
+	s = strings.TrimSpace(s)
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	n := 1
+	if digits > 0 {
+		v, err := strconv.Atoi(s[:digits])
+		if err != nil {
+			return nil, fmt.Errorf("invalid frequency %q: %w", s, err)
+		}
+		n = v
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("invalid frequency %q: multiple must be positive", s)
+	}
+	unit := s[digits:]
+
+	if d, ok := fixedUnits[unit]; ok {
+		return Every(time.Duration(n) * d), nil
+	}
+	switch unit {
+	case "D":
+		return Days(n), nil
+	case "B":
+		return BusinessDays(n), nil
+	case "W":
+		return Weeks(n, time.Sunday), nil
+	case "MS":
+		return MonthStart(n), nil
+	case "M", "ME":
+		return MonthEnd(n), nil
+	case "QS":
+		return QuarterStart(n), nil
+	case "Q", "QE":
+		return QuarterEnd(n), nil
+	case "YS", "AS":
+		return YearStart(n), nil
+	case "Y", "YE", "A":
+		return YearEnd(n), nil
+	}
+	if day, ok := strings.CutPrefix(unit, "W-"); ok {
+		if wd, ok := weekdayNames[strings.ToUpper(day)]; ok {
+			return Weeks(n, wd), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return Every(d), nil
+	}
+	return nil, fmt.Errorf("invalid frequency %q: unknown unit %q", s, unit)
+}
@@ -0,0 +1,234 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseFrequency(t *testing.T) {
+	cases := map[string]string{
+		"15min": "15min",
+		"1D":    "D",
+		"W-MON": "W-MON",
+		"W":     "W-SUN",
+		"MS":    "MS",
+		"M":     "ME",
+		"3MS":   "3MS",
+		"QE":    "QE",
+		"YS":    "YS",
+		"B":     "B",
+		"2h":    "2h",
+		"1h30m": "90min",
+		"500ms": "500ms",
+	}
+	for in, want := range cases {
+		f, err := ParseFrequency(in)
+		if err != nil {
+			t.Errorf("ParseFrequency(%q) returned error %v", in, err)
+			continue
+		}
+		if f.String() != want {
+			t.Errorf("ParseFrequency(%q).String() = %q, want %q", in, f.String(), want)
+		}
+	}
+	for _, in := range []string{"", "0D", "5X", "W-FOO"} {
+		if _, err := ParseFrequency(in); err == nil {
+			t.Errorf("expected error for %q", in)
+		}
+	}
+}
+
+func TestCalendarFloorAndShift(t *testing.T) {
+	at := time.Date(2024, 1, 31, 15, 30, 0, 0, time.UTC) // a Wednesday
+	cases := []struct {
+		freq  Frequency
+		floor time.Time
+		next  time.Time
+	}{
+		{Days(1), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Weeks(1, time.Monday), time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)},
+		{MonthStart(1), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{MonthEnd(1), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{QuarterStart(1), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{QuarterEnd(1), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{YearStart(1), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{YearEnd(1), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{BusinessDays(1), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		floor := c.freq.Floor(at)
+		if !floor.Equal(c.floor) {
+			t.Errorf("%s: floor expected %v, got %v", c.freq, c.floor, floor)
+		}
+		if next := c.freq.Shift(floor, 1); !next.Equal(c.next) {
+			t.Errorf("%s: next tick expected %v, got %v", c.freq, c.next, next)
+		}
+	}
+
+	// Month ends stay on month ends, including across short months.
+	tick := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for _, want := range []int{29, 31, 30, 31} {
+		tick = MonthEnd(1).Shift(tick, 1)
+		if tick.Day() != want {
+			t.Errorf("expected month end day %d, got %v", want, tick)
+		}
+	}
+
+	friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+	if got := BusinessDays(1).Shift(friday, 1); got.Weekday() != time.Monday || got.Day() != 10 {
+		t.Errorf("expected business day after Friday to be Monday 10th, got %v", got)
+	}
+	if got := BusinessDays(1).Shift(friday, -6); !got.Equal(time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected six business days before to be May 30th, got %v", got)
+	}
+	saturday := time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC)
+	if got := BusinessDays(1).Floor(saturday); !got.Equal(friday) {
+		t.Errorf("expected Saturday to floor to Friday, got %v", got)
+	}
+}
+
+func TestBusinessDayShiftFromEveryWeekday(t *testing.T) {
+	// naive steps one calendar day at a time.
+	naive := func(at time.Time, n int) time.Time {
+		step := 1
+		if n < 0 {
+			step, n = -1, -n
+		}
+		for n > 0 {
+			at = at.AddDate(0, 0, step)
+			if wd := at.Weekday(); wd != time.Saturday && wd != time.Sunday {
+				n--
+			}
+		}
+		return at
+	}
+	for d := 3; d <= 9; d++ { // Monday June 3rd to Sunday June 9th 2024
+		at := time.Date(2024, 6, d, 12, 0, 0, 0, time.UTC)
+		for n := -6; n <= 6; n++ {
+			if got, want := BusinessDays(1).Shift(at, n), naive(at, n); !got.Equal(want) {
+				t.Errorf("%s %+d: expected %v, got %v", at.Weekday(), n, want, got)
+			}
+		}
+	}
+}
+
+func TestCalendarMultiplesFloorOnFixedGrid(t *testing.T) {
+	cases := []struct {
+		freq Frequency
+		at   time.Time
+		want time.Time
+	}{
+		// Days since 1970-01-01: June 9th 2024 is odd, June 8th even.
+		{Days(2), time.Date(2024, 6, 9, 15, 0, 0, 0, time.UTC), time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC)},
+		{Weeks(2, time.Monday), time.Date(2024, 6, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{BusinessDays(2), time.Date(2024, 6, 11, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{MonthStart(3), time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{MonthEnd(2), time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{YearStart(2), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got := c.freq.Floor(c.at); !got.Equal(c.want) {
+			t.Errorf("%s: floor of %v expected %v, got %v", c.freq, c.at, c.want, got)
+		}
+	}
+
+	// Floor is the last grid timestamp at or before t, for every hour of a year.
+	freqs := []Frequency{Days(3), Weeks(2, time.Friday), BusinessDays(3), MonthStart(2), MonthEnd(5), QuarterEnd(2), YearEnd(3)}
+	for _, freq := range freqs {
+		for at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); at.Year() == 2024; at = at.Add(7 * time.Hour) {
+			floor := freq.Floor(at)
+			if floor.After(at) || !freq.Shift(floor, 1).After(at) || !freq.Floor(floor).Equal(floor) {
+				t.Fatalf("%s: floor of %v is %v, next tick %v", freq, at, floor, freq.Shift(floor, 1))
+			}
+		}
+	}
+}
+
+func TestDaysKeepLocalMidnightAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	start := time.Date(2024, 3, 30, 0, 0, 0, 0, loc)
+	var got []time.Time
+	for tick := range Ticks(Days(1), start, start.AddDate(0, 0, 2)) {
+		got = append(got, tick)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 ticks, got %d", len(got))
+	}
+	for _, tick := range got {
+		if tick.Hour() != 0 {
+			t.Errorf("expected local midnight, got %v", tick)
+		}
+	}
+	if d := got[2].Sub(got[1]); d != 23*time.Hour {
+		t.Errorf("expected the DST day to last 23h, got %v", d)
+	}
+}
+
+func TestTicksRollsStartOntoGrid(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	var got []time.Time
+	for tick := range Ticks(MonthStart(1), start, end) {
+		got = append(got, tick)
+	}
+	if len(got) != 3 || got[0].Month() != time.February || got[2].Month() != time.April {
+		t.Errorf("unexpected month starts %v", got)
+	}
+	for range Ticks(Every(0), start, end) {
+		t.Fatal("a zero frequency must not yield ticks")
+	}
+}
+
+func TestResampleAndStepWithCalendarFrequency(t *testing.T) {
+	ts := Empty()
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 0})
+	ts.AddPoint(DataPoint{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 60})
+
+	lin := ts.InterpolateWithFrequency(MonthStart(1))
+	if lin.Length() != 3 {
+		t.Fatalf("expected 3 monthly points, got %d", lin.Length())
+	}
+	// 31 of the 60 days have passed on February 1st (2024 is a leap year).
+	if v := lin.Values()[1]; math.Abs(v-31) > 1e-9 {
+		t.Errorf("expected 31 on February 1st, got %v", v)
+	}
+
+	// Step splits the 60 of January 1st across the months in proportion to their length.
+	first := Empty()
+	first.AddPoint(DataPoint{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 60})
+	first.AddPoint(DataPoint{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 0})
+	stepped := first.StepWithFrequency(MonthStart(1))
+	expected := []float64{31, 29}
+	if stepped.Length() != len(expected) {
+		t.Fatalf("expected %d stepped points, got %d", len(expected), stepped.Length())
+	}
+	for i, v := range stepped.Values() {
+		if math.Abs(v-expected[i]) > 1e-9 {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], v)
+		}
+	}
+}
+
+func TestRollingWindowWithFrequency(t *testing.T) {
+	ts := Empty()
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), 1})
+	ts.AddPoint(DataPoint{time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), 2})
+	ts.AddPoint(DataPoint{time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC), 4})
+	rolled := ts.RollingWindowWithFrequency(MonthStart(1), func(vs []float64) float64 {
+		s := 0.0
+		for _, v := range vs {
+			s += v
+		}
+		return s
+	})
+	expected := []float64{1, 3, 6}
+	for i, v := range rolled.Values() {
+		if v != expected[i] {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], v)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package timeseriesgo
+
+import "time"
+
+// Gap is a run of missing ticks between two consecutive points of a series.
+type Gap struct {
+	// Start is the first missing tick.
+	Start time.Time
+	// End is the last missing tick.
+	End time.Time
+	// Missing is the number of missing ticks from Start to End inclusive.
+	Missing int
+}
+
+// gapAfter is a Gap together with the index of the point it follows.
+type gapAfter struct {
+	after int
+	gap   Gap
+}
+
+/**
+ * Finds the missing ticks of a sorted series with an expected frequency.
+ * Only the span between the first and last point is checked.
+ *
+ * @param expected The frequency the series should have, e.g. Every(time.Minute) or BusinessDays(1).
+ * @param tolerance How far a point may be from its tick and still count; ticks within tolerance
+ *                  before the next point are not reported.
+ *
+ * @return The gaps in chronological order. A frequency that does not advance finds no gaps.
+ */
+func (s *Series[T]) Gaps(expected Frequency, tolerance time.Duration) []Gap {
+	var res []Gap
+	for _, g := range s.gaps(expected, tolerance) {
+		res = append(res, g.gap)
+	}
+	return res
+}
+
+func (s *Series[T]) gaps(expected Frequency, tolerance time.Duration) []gapAfter {
+	var res []gapAfter
+	if len(s.times) < 2 || !advances(expected, s.timeAt(0)) {
+		return res
+	}
+	for i := 1; i < len(s.times); i++ {
+		prev := s.timeAt(i - 1)
+		limit := s.times[i] - int64(tolerance)
+		first := expected.Shift(prev, 1)
+		if first.UnixNano() >= limit {
+			continue
+		}
+		g := Gap{Start: first, End: first, Missing: 1}
+		for t := expected.Shift(first, 1); t.UnixNano() < limit; t = expected.Shift(t, 1) {
+			g.End = t
+			g.Missing++
+		}
+		res = append(res, gapAfter{after: i - 1, gap: g})
+	}
+	return res
+}
+
+// Gaps is Series.Gaps for float64 values.
+func (ts *TimeSeries) Gaps(expected Frequency, tolerance time.Duration) []Gap {
+	return ts.core().Gaps(expected, tolerance)
+}
+
+// GapFillOptions configures FillGaps.
+type GapFillOptions struct {
+	// Method fills the missing ticks: FillForward, FillBackward, FillNearest, FillLinear,
+	// FillConstant or FillSeasonal.
+	Method FillMethod
+	// Value is used by FillConstant.
+	Value float64
+	// Season is the number of ticks in one season for FillSeasonal, e.g. 7 for daily data with a weekly pattern.
+	Season int
+	// MaxFill leaves gaps with more missing ticks than this empty; 0 means no limit.
+	MaxFill int
+	// Tolerance is passed to Gaps.
+	Tolerance time.Duration
+}
+
+/**
+ * Inserts points at the missing ticks found by Gaps. Existing points are kept unchanged.
+ * FillSeasonal copies the value Season ticks earlier (from the input or an already filled tick)
+ * and leaves a tick empty when there is none. An unknown method fills NaN.
+ *
+ * @param expected The frequency the series should have.
+ * @param opts The fill method and its limits.
+ *
+ * @return A new sorted TimeSeries with the filled ticks.
+ */
+func (ts *TimeSeries) FillGaps(expected Frequency, opts GapFillOptions) TimeSeries {
+	res := ts.derive()
+	gaps := ts.core().gaps(expected, opts.Tolerance)
+	g := 0
+	for i := range ts.times {
+		res.appendRaw(ts.times[i], ts.values[i])
+		for ; g < len(gaps) && gaps[g].after == i; g++ {
+			gap := gaps[g].gap
+			if opts.MaxFill > 0 && gap.Missing > opts.MaxFill {
+				continue
+			}
+			t := gap.Start
+			for k := 0; k < gap.Missing; k, t = k+1, expected.Shift(t, 1) {
+				n := t.UnixNano()
+				if opts.Method != FillSeasonal {
+					res.appendRaw(n, fillBetween(opts.Method, ts.times[i], ts.values[i], ts.times[i+1], ts.values[i+1], n, opts.Value))
+					continue
+				}
+				if opts.Season <= 0 {
+					continue
+				}
+				if j := res.IndexOf(expected.Shift(t, -opts.Season)); j >= 0 {
+					res.appendRaw(n, res.values[j])
+				}
+			}
+		}
+	}
+	return res
+}
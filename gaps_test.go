@@ -0,0 +1,101 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGaps(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 4, 5, 9}, []float64{1, 2, 5, 6, 10})
+	gaps := ts.Gaps(Every(time.Minute), 0)
+	expected := []Gap{
+		{Start: base.Add(2 * time.Minute), End: base.Add(3 * time.Minute), Missing: 2},
+		{Start: base.Add(6 * time.Minute), End: base.Add(8 * time.Minute), Missing: 3},
+	}
+	if len(gaps) != len(expected) {
+		t.Fatalf("expected %d gaps, got %d", len(expected), len(gaps))
+	}
+	for i, g := range gaps {
+		if !g.Start.Equal(expected[i].Start) || !g.End.Equal(expected[i].End) || g.Missing != expected[i].Missing {
+			t.Errorf("gap %d expected %+v, got %+v", i, expected[i], g)
+		}
+	}
+}
+
+func TestGapsTolerance(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	ts.AddPoint(DataPoint{base, 1})
+	ts.AddPoint(DataPoint{base.Add(time.Minute + 2*time.Second), 2})
+	ts.AddPoint(DataPoint{base.Add(3*time.Minute + 2*time.Second), 3})
+	if gaps := ts.Gaps(Every(time.Minute), 0); len(gaps) != 2 {
+		t.Errorf("expected jitter to show up as 2 gaps without tolerance, got %d", len(gaps))
+	}
+	gaps := ts.Gaps(Every(time.Minute), 5*time.Second)
+	if len(gaps) != 1 || gaps[0].Missing != 1 {
+		t.Errorf("expected one missing tick with tolerance, got %+v", gaps)
+	}
+}
+
+func TestGapsCalendar(t *testing.T) {
+	ts := Empty()
+	ts.AddPoint(DataPoint{time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC), 1})  // Friday
+	ts.AddPoint(DataPoint{time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), 2}) // Monday
+	ts.AddPoint(DataPoint{time.Date(2024, 6, 13, 0, 0, 0, 0, time.UTC), 3}) // Thursday
+	gaps := ts.Gaps(BusinessDays(1), 0)
+	if len(gaps) != 1 || gaps[0].Missing != 2 || gaps[0].Start.Day() != 11 {
+		t.Errorf("expected Tuesday and Wednesday missing, got %+v", gaps)
+	}
+}
+
+func TestFillGaps(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 3, 4, 9}, []float64{0, 3, 4, 9})
+	ts.SetUnit("kW")
+
+	cases := []struct {
+		opts GapFillOptions
+		want []float64
+	}{
+		{GapFillOptions{Method: FillForward}, []float64{0, 0, 0, 3, 4, 4, 4, 4, 4, 9}},
+		{GapFillOptions{Method: FillBackward}, []float64{0, 3, 3, 3, 4, 9, 9, 9, 9, 9}},
+		{GapFillOptions{Method: FillLinear}, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{GapFillOptions{Method: FillConstant, Value: -1}, []float64{0, -1, -1, 3, 4, -1, -1, -1, -1, 9}},
+		{GapFillOptions{Method: FillLinear, MaxFill: 2}, []float64{0, 1, 2, 3, 4, 9}},
+	}
+	for i, c := range cases {
+		got := ts.FillGaps(Every(time.Minute), c.opts)
+		if got.Length() != len(c.want) {
+			t.Fatalf("case %d: expected %d points, got %d", i, len(c.want), got.Length())
+		}
+		for k, v := range got.Values() {
+			if math.Abs(v-c.want[k]) > 1e-9 {
+				t.Errorf("case %d idx %d expected %v, got %v", i, k, c.want[k], v)
+			}
+		}
+		if err := got.Validate(); err != nil {
+			t.Errorf("case %d: filled series is not sorted: %v", i, err)
+		}
+		if got.Unit() != "kW" {
+			t.Errorf("case %d: expected unit to be kept", i)
+		}
+	}
+}
+
+func TestFillGapsSeasonal(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	// A pattern of period 3 with ticks 4, 5 and 7 missing.
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 6, 8}, []float64{1, 2, 3, 1, 1, 3})
+	got := ts.FillGaps(Every(time.Minute), GapFillOptions{Method: FillSeasonal, Season: 3})
+	expected := []float64{1, 2, 3, 1, 2, 3, 1, 2, 3}
+	if got.Length() != len(expected) {
+		t.Fatalf("expected %d points, got %d", len(expected), got.Length())
+	}
+	for i, v := range got.Values() {
+		if v != expected[i] {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], v)
+		}
+	}
+}
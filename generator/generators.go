@@ -24,6 +24,26 @@ func MakeSeriesIndex(start time.Time, interval time.Duration, count int) []time.
 	return ts
 }
 
+/**
+ * Creates a slice of timestamps on the grid of a Frequency, e.g. the next twelve month ends.
+ *
+ * @param start The first timestamp; it is rolled forward onto the grid when it is not on it.
+ * @param freq The frequency of the index.
+ * @param count The number of timestamps to generate.
+ *
+ * @return A slice of time.Time with count timestamps, or an empty slice if freq does not advance.
+ */
+func MakeSeriesIndexWithFrequency(start time.Time, freq timeseriesgo.Frequency, count int) []time.Time {
+	ts := []time.Time{}
+	if count <= 0 || !freq.Shift(start, 1).After(start) {
+		return ts
+	}
+	for t := timeseriesgo.Ceil(freq, start); len(ts) < count; t = freq.Shift(t, 1) {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
 /**
  * Generates a TimeSeries with constant value at specified timestamps.
  *
@@ -69,24 +89,38 @@ func RandomWalk(index []time.Time, startValue float64) timeseriesgo.TimeSeries {
 	return ts
 }
 
+/**
+ * Repeats the values of pattern from start until end, stepping by the frequency inferred from pattern.
+ * A pattern whose frequency cannot be inferred (a single point) is returned as-is.
+ */
 func Repeat(pattern timeseriesgo.TimeSeries, start time.Time, end time.Time) timeseriesgo.TimeSeries {
 	if pattern.IsEmpty() {
 		return timeseriesgo.Empty()
-	} else {
-		ts := timeseriesgo.Empty()
-		resolution, err := pattern.Resolution()
-		if err != nil {
-			return pattern
-		}
-		i := 0
-		vs := pattern.Values()
-		for now := start; now.Before(end); now = now.Add(resolution) {
-			if i == pattern.Length() {
-				i = 0
-			}
-			ts.AddPoint(timeseriesgo.DataPoint{Timestamp: now, Value: vs[i]})
-			i++
-		}
+	}
+	inferred, err := pattern.InferFrequency()
+	if err != nil {
+		return pattern
+	}
+	return RepeatWithFrequency(pattern, start, end, inferred.Frequency)
+}
+
+/**
+ * Repeats the values of pattern on the grid of freq, from start (inclusive) until end (exclusive).
+ * Unlike Repeat this also works for single-point patterns and calendar steps such as MonthStart(1).
+ */
+func RepeatWithFrequency(pattern timeseriesgo.TimeSeries, start time.Time, end time.Time, freq timeseriesgo.Frequency) timeseriesgo.TimeSeries {
+	ts := timeseriesgo.Empty()
+	if pattern.IsEmpty() || !freq.Shift(start, 1).After(start) {
 		return ts
 	}
+	i := 0
+	vs := pattern.ValuesView()
+	for now := start; now.Before(end); now = freq.Shift(now, 1) {
+		if i == len(vs) {
+			i = 0
+		}
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: now, Value: vs[i]})
+		i++
+	}
+	return ts
 }
@@ -95,3 +95,38 @@ func TestRepeatSinglePointPattern(t *testing.T) {
 		t.Errorf("Expected original datapoint preserved, got %+v", points[0])
 	}
 }
+
+func TestMakeSeriesIndexWithFrequency(t *testing.T) {
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	index := MakeSeriesIndexWithFrequency(start, timeseriesgo.MonthEnd(1), 3)
+	expected := []time.Time{
+		time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(index) != len(expected) {
+		t.Fatalf("expected %d timestamps, got %d", len(expected), len(index))
+	}
+	for i := range expected {
+		if !index[i].Equal(expected[i]) {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], index[i])
+		}
+	}
+	if len(MakeSeriesIndexWithFrequency(start, timeseriesgo.Every(0), 3)) != 0 {
+		t.Errorf("expected empty index for a zero frequency")
+	}
+}
+
+func TestRepeatWithFrequency(t *testing.T) {
+	pattern := timeseriesgo.Empty()
+	pattern.AddPoint(timeseriesgo.DataPoint{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 1})
+	pattern.AddPoint(timeseriesgo.DataPoint{Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Value: 2})
+	start := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC) // Friday
+	ts := RepeatWithFrequency(pattern, start, start.AddDate(0, 0, 5), timeseriesgo.BusinessDays(1))
+	if ts.Length() != 3 {
+		t.Fatalf("expected 3 business days, got %d", ts.Length())
+	}
+	if ts.Timestamps()[1].Weekday() != time.Monday || ts.Values()[2] != 1 {
+		t.Errorf("unexpected repeat %v %v", ts.Timestamps(), ts.Values())
+	}
+}
@@ -0,0 +1,170 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// InferredFrequency is the result of InferFrequency.
+type InferredFrequency struct {
+	// Frequency is the grid that best explains the timestamps.
+	Frequency Frequency
+	// Confidence is the fraction of points that sit on the grid (1 for a perfectly regular series).
+	Confidence float64
+	// Coverage is the fraction of grid ticks between the first and last point that hold a point.
+	// Dropped samples lower it; they do not change the inferred frequency.
+	Coverage float64
+}
+
+/**
+ * Infers the frequency of a sorted series. Calendar frequencies (years, quarters, months,
+ * weeks, business days and days, start or end anchored) are tried before the most frequent
+ * gap between points, so daily data in a DST zone is reported as Days(1), not 24 hours.
+ * Each calendar frequency is also tried at the multiple closest to the most frequent gap,
+ * so data every other month is MonthStart(2), with its grid moved to go through the first point.
+ * The candidate with the highest Confidence*Coverage wins; on a tie the coarser calendar
+ * frequency is preferred.
+ *
+ * @return The inferred frequency with its scores, or an error for series with fewer than two distinct timestamps.
+ */
+func (s *Series[T]) InferFrequency() (InferredFrequency, error) {
+	if len(s.times) < 2 {
+		return InferredFrequency{}, errors.New("at least two points are required to infer a frequency")
+	}
+	modal, ok := s.modalGap()
+	if !ok {
+		return InferredFrequency{}, errors.New("all timestamps are equal")
+	}
+
+	first := s.timeAt(0)
+	candidates := []Frequency{
+		YearStart(1), YearEnd(1), QuarterStart(1), QuarterEnd(1), MonthStart(1), MonthEnd(1),
+		Weeks(1, first.Weekday()), BusinessDays(1), Days(1),
+	}
+
+	var best InferredFrequency
+	bestScore := -1.0
+	for _, freq := range candidates {
+		r, ok := s.scoreCalendar(freq)
+		if !ok {
+			continue
+		}
+		// Data every k periods only covers 1/k of the single-period grid; score the multiple
+		// too, with its phase taken from the first point so the grid goes through the data.
+		if k := calendarMultiple(freq.(calendarFrequency), modal); k > 1 {
+			multiple := freq.(calendarFrequency)
+			multiple.n = k
+			multiple.phase = multiple.period(freq.Floor(first))
+			if rk, ok := s.scoreCalendar(multiple); ok && rk.Confidence*rk.Coverage > r.Confidence*r.Coverage {
+				r = rk
+			}
+		}
+		if r.Confidence*r.Coverage > bestScore {
+			best, bestScore = r, r.Confidence*r.Coverage
+		}
+	}
+	if r := s.scoreFixed(modal); r.Confidence*r.Coverage > bestScore {
+		best = r
+	}
+	return best, nil
+}
+
+// modalGap returns the most frequent positive gap between consecutive points (the smallest on a tie).
+func (s *Series[T]) modalGap() (time.Duration, bool) {
+	counts := make(map[int64]int)
+	var mode int64
+	modeCount := 0
+	for i := 1; i < len(s.times); i++ {
+		d := s.times[i] - s.times[i-1]
+		if d <= 0 {
+			continue
+		}
+		counts[d]++
+		if c := counts[d]; c > modeCount || (c == modeCount && d < mode) {
+			mode, modeCount = d, c
+		}
+	}
+	return time.Duration(mode), modeCount > 0
+}
+
+// nominalLength is the average length of one period of each calendar unit.
+var nominalLength = map[calendarUnit]time.Duration{
+	unitDay:         24 * time.Hour,
+	unitWeek:        7 * 24 * time.Hour,
+	unitBusinessDay: 24 * time.Hour,
+	unitMonth:       time.Duration(30.436875 * float64(24*time.Hour)),
+	unitQuarter:     time.Duration(3 * 30.436875 * float64(24*time.Hour)),
+	unitYear:        time.Duration(12 * 30.436875 * float64(24*time.Hour)),
+}
+
+// calendarMultiple returns how many periods of freq the gap d spans, rounded to the nearest whole number.
+func calendarMultiple(freq calendarFrequency, d time.Duration) int {
+	return int(math.Round(float64(d) / float64(nominalLength[freq.unit])))
+}
+
+// scoreCalendar scores a calendar frequency on its tick lattice starting at the floor of the
+// first point. It gives up
+// (ok == false) when fewer than half the points are on the grid, which also keeps it from
+// walking a daily grid under sub-daily data.
+func (s *Series[T]) scoreCalendar(freq Frequency) (InferredFrequency, bool) {
+	onFloor := 0
+	for i := range s.times {
+		if t := s.timeAt(i); freq.Floor(t).Equal(t) {
+			onFloor++
+		}
+	}
+	// Floor is cheap, so rule out grids that miss most points before walking the ticks.
+	if 2*onFloor < len(s.times) {
+		return InferredFrequency{}, false
+	}
+	onGrid, hits, ticks, i := 0, 0, 0, 0
+	for tick := range Ticks(freq, freq.Floor(s.timeAt(0)), s.timeAt(len(s.times)-1)) {
+		ticks++
+		n := tick.UnixNano()
+		for i < len(s.times) && s.times[i] < n {
+			i++
+		}
+		if i < len(s.times) && s.times[i] == n {
+			hits++
+			for i < len(s.times) && s.times[i] == n {
+				onGrid++
+				i++
+			}
+		}
+	}
+	if 2*onGrid < len(s.times) {
+		return InferredFrequency{}, false
+	}
+	return InferredFrequency{
+		Frequency:  freq,
+		Confidence: float64(onGrid) / float64(len(s.times)),
+		Coverage:   float64(hits) / float64(ticks),
+	}, true
+}
+
+// scoreFixed scores a fixed grid of step d anchored at the first point.
+func (s *Series[T]) scoreFixed(d time.Duration) InferredFrequency {
+	step := int64(d)
+	origin := s.times[0]
+	onGrid, hits := 0, 0
+	for i, t := range s.times {
+		if (t-origin)%step == 0 {
+			onGrid++
+			if i == 0 || t != s.times[i-1] {
+				hits++
+			}
+		}
+	}
+	ticks := (s.times[len(s.times)-1]-origin)/step + 1
+	return InferredFrequency{
+		Frequency:  Every(d),
+		Confidence: float64(onGrid) / float64(len(s.times)),
+		Coverage:   float64(hits) / float64(ticks),
+	}
+}
+
+// InferFrequency is Series.InferFrequency for float64 values.
+func (ts *TimeSeries) InferFrequency() (InferredFrequency, error) {
+	return ts.core().InferFrequency()
+}
@@ -0,0 +1,146 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func seriesAt(times ...time.Time) TimeSeries {
+	ts := Empty()
+	for i, t := range times {
+		ts.AddPoint(DataPoint{t, float64(i)})
+	}
+	return ts
+}
+
+func TestInferFrequencyCalendar(t *testing.T) {
+	var monthEnds, monthStarts, weekdays []time.Time
+	for m := time.January; m <= time.June; m++ {
+		monthStarts = append(monthStarts, time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC))
+		monthEnds = append(monthEnds, time.Date(2024, m+1, 0, 0, 0, 0, 0, time.UTC))
+	}
+	for d := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC); d.Month() == time.June; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			weekdays = append(weekdays, d)
+		}
+	}
+	cases := []struct {
+		name  string
+		times []time.Time
+		want  string
+	}{
+		{"month start", monthStarts, "MS"},
+		{"month end", monthEnds, "ME"},
+		{"business days", weekdays, "B"},
+		{"weekly", []time.Time{
+			time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC),
+		}, "W-MON"},
+		{"hourly", []time.Time{
+			time.Date(2024, 6, 3, 1, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 3, 2, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 3, 3, 0, 0, 0, time.UTC),
+		}, "1h"},
+	}
+	for _, c := range cases {
+		ts := seriesAt(c.times...)
+		got, err := ts.InferFrequency()
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+		if got.Frequency.String() != c.want {
+			t.Errorf("%s: expected %s, got %s", c.name, c.want, got.Frequency)
+		}
+		if got.Confidence != 1 || got.Coverage != 1 {
+			t.Errorf("%s: expected full confidence and coverage, got %v and %v", c.name, got.Confidence, got.Coverage)
+		}
+	}
+}
+
+func TestInferFrequencyWithDroppedSamples(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for i := 0; i < 10; i++ {
+		if i == 3 || i == 7 {
+			continue
+		}
+		times = append(times, base.Add(time.Duration(i)*15*time.Minute))
+	}
+	ts := seriesAt(times...)
+	got, err := ts.InferFrequency()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Frequency.String() != "15min" {
+		t.Errorf("expected 15min, got %s", got.Frequency)
+	}
+	if math.Abs(got.Coverage-0.8) > 1e-9 || got.Confidence != 1 {
+		t.Errorf("expected coverage 0.8 and confidence 1, got %v and %v", got.Coverage, got.Confidence)
+	}
+}
+
+func TestInferFrequencyAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	var times []time.Time
+	for d := 5; d < 15; d++ {
+		times = append(times, time.Date(2024, 3, d, 0, 0, 0, 0, loc))
+	}
+	ts := seriesAt(times...)
+	got, err := ts.InferFrequency()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Frequency.String() != "D" || got.Coverage != 1 {
+		t.Errorf("expected daily with full coverage, got %s with %v", got.Frequency, got.Coverage)
+	}
+}
+
+func TestInferFrequencyCalendarMultiples(t *testing.T) {
+	var twoMonthly, biweekly, everyOtherDay, dropped []time.Time
+	for k := 0; k < 6; k++ {
+		// Starting in February: the lattice is anchored at the data, not at January.
+		twoMonthly = append(twoMonthly, time.Date(2024, time.February+time.Month(2*k), 1, 0, 0, 0, 0, time.UTC))
+		biweekly = append(biweekly, time.Date(2024, 1, 3+14*k, 0, 0, 0, 0, time.UTC))
+		everyOtherDay = append(everyOtherDay, time.Date(2024, 3, 1+2*k, 0, 0, 0, 0, time.UTC))
+	}
+	for k := 0; k < 12; k++ {
+		if k != 5 {
+			dropped = append(dropped, time.Date(2024, time.Month(2+2*k), 0, 0, 0, 0, 0, time.UTC))
+		}
+	}
+	cases := []struct {
+		name     string
+		times    []time.Time
+		want     string
+		coverage float64
+	}{
+		{"two-monthly", twoMonthly, "2MS", 1},
+		{"biweekly", biweekly, "2W-WED", 1},
+		{"every other day", everyOtherDay, "2D", 1},
+		{"two-monthly with a dropped sample", dropped, "2ME", 11.0 / 12},
+	}
+	for _, c := range cases {
+		ts := seriesAt(c.times...)
+		got, err := ts.InferFrequency()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Frequency.String() != c.want || got.Confidence != 1 || math.Abs(got.Coverage-c.coverage) > 1e-9 {
+			t.Errorf("%s: expected %s with coverage %v, got %s (%v, %v)", c.name, c.want, c.coverage, got.Frequency, got.Confidence, got.Coverage)
+		}
+	}
+}
+
+func TestInferFrequencyErrors(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, ts := range []TimeSeries{Empty(), seriesAt(base), seriesAt(base, base)} {
+		if _, err := ts.InferFrequency(); err == nil {
+			t.Errorf("expected error for %d points", ts.Length())
+		}
+	}
+}
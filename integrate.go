@@ -0,0 +1,85 @@
+package timeseriesgo
+
+import (
+	"math"
+	"time"
+)
+
+// IntegrationRule selects how IntegrateOverTime values the series between two points.
+type IntegrationRule int
+
+const (
+	// IntegrateTrapezoid interpolates linearly between neighbouring points.
+	IntegrateTrapezoid IntegrationRule = iota
+	// IntegrateLeft holds each value until the next point, for readings that apply from their timestamp on.
+	IntegrateLeft
+	// IntegrateRight applies each value back to the previous point, for readings that cover the interval ending at their timestamp.
+	IntegrateRight
+)
+
+/**
+ * Returns the cumulative sum of the values; NaN values are skipped and repeat the running total.
+ */
+func (ts *TimeSeries) CumSum() TimeSeries {
+	return ts.Expanding().Sum()
+}
+
+// area returns the integral of the interval between points i-1 and i, in value*unit.
+// Intervals whose relevant endpoint is NaN contribute nothing.
+func (ts *TimeSeries) area(i int, rule IntegrationRule, unit time.Duration) float64 {
+	width := float64(ts.times[i]-ts.times[i-1]) / float64(unit)
+	var height float64
+	switch rule {
+	case IntegrateLeft:
+		height = ts.values[i-1]
+	case IntegrateRight:
+		height = ts.values[i]
+	default:
+		height = (ts.values[i-1] + ts.values[i]) / 2
+	}
+	if math.IsNaN(height) {
+		return 0
+	}
+	return height * width
+}
+
+/**
+ * Returns the running integral of a sorted series over time, from 0 at the first point.
+ * Spacing may be irregular: every interval is weighted by its own length.
+ * Example: power in kW integrated with unit time.Hour gives energy in kWh;
+ * bytes/s with unit time.Second gives bytes.
+ *
+ * @param rule How values are taken between points.
+ * @param unit The time unit the values are expressed per; 0 means time.Second.
+ *
+ * @return A TimeSeries with the same timestamps holding the accumulated total.
+ */
+func (ts *TimeSeries) IntegrateOverTime(rule IntegrationRule, unit time.Duration) TimeSeries {
+	if unit <= 0 {
+		unit = time.Second
+	}
+	res := ts.derive()
+	total := 0.0
+	for i, t := range ts.times {
+		if i > 0 {
+			total += ts.area(i, rule, unit)
+		}
+		res.appendRaw(t, total)
+	}
+	return res
+}
+
+/**
+ * Returns the integral of a sorted series over its whole span, see IntegrateOverTime.
+ * A series with fewer than two points integrates to 0.
+ */
+func (ts *TimeSeries) TotalOverTime(rule IntegrationRule, unit time.Duration) float64 {
+	if unit <= 0 {
+		unit = time.Second
+	}
+	total := 0.0
+	for i := 1; i < len(ts.times); i++ {
+		total += ts.area(i, rule, unit)
+	}
+	return total
+}
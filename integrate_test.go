@@ -0,0 +1,34 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCumSum(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3}, []float64{1, 2, math.NaN(), 4})
+	assertSeriesValues(t, "cumsum", ts.CumSum(), []float64{1, 3, 3, 7})
+}
+
+func TestIntegrateOverTime(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Power in kW at irregular times: 00:00, 00:30, 02:00.
+	ts := Empty()
+	ts.AddPoint(DataPoint{base, 2})
+	ts.AddPoint(DataPoint{base.Add(30 * time.Minute), 4})
+	ts.AddPoint(DataPoint{base.Add(2 * time.Hour), 0})
+
+	assertSeriesValues(t, "trapezoid", ts.IntegrateOverTime(IntegrateTrapezoid, time.Hour), []float64{0, 1.5, 4.5})
+	assertSeriesValues(t, "left", ts.IntegrateOverTime(IntegrateLeft, time.Hour), []float64{0, 1, 7})
+	assertSeriesValues(t, "right", ts.IntegrateOverTime(IntegrateRight, time.Hour), []float64{0, 2, 2})
+
+	if got := ts.TotalOverTime(IntegrateTrapezoid, 0); got != 4.5*3600 {
+		t.Errorf("expected %v kW*s, got %v", 4.5*3600, got)
+	}
+	single := minuteSeries(base, []int{0}, []float64{5})
+	if got := single.TotalOverTime(IntegrateLeft, time.Hour); got != 0 {
+		t.Errorf("expected 0 for a single point, got %v", got)
+	}
+}
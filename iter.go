@@ -0,0 +1,106 @@
+package timeseriesgo
+
+import (
+	"iter"
+	"time"
+)
+
+// The iterators below read the columns in place, so ranging over a series
+// does not materialise a copy of its points.
+
+// All yields every timestamp with its value in order.
+func (s *Series[T]) All() iter.Seq2[time.Time, T] {
+	return func(yield func(time.Time, T) bool) {
+		for i := range s.times {
+			if !yield(s.timeAt(i), s.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Points yields every point in order.
+func (s *Series[T]) Points() iter.Seq[Point[T]] {
+	return func(yield func(Point[T]) bool) {
+		for i := range s.times {
+			if !yield(s.pointAt(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields every timestamp with its value from last to first.
+func (s *Series[T]) Backward() iter.Seq2[time.Time, T] {
+	return func(yield func(time.Time, T) bool) {
+		for i := len(s.times) - 1; i >= 0; i-- {
+			if !yield(s.timeAt(i), s.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// CollectSeries builds a Series from a sequence of timestamp/value pairs.
+func CollectSeries[T any](seq iter.Seq2[time.Time, T]) Series[T] {
+	s := EmptySeries[T]("new series")
+	for t, v := range seq {
+		s.AddPoint(Point[T]{Timestamp: t, Value: v})
+	}
+	return s
+}
+
+// All yields every timestamp with its value in order.
+func (ts *TimeSeries) All() iter.Seq2[time.Time, float64] {
+	return ts.core().All()
+}
+
+// Points yields every datapoint in order.
+func (ts *TimeSeries) Points() iter.Seq[DataPoint] {
+	return ts.core().Points()
+}
+
+// Backward yields every timestamp with its value from last to first.
+func (ts *TimeSeries) Backward() iter.Seq2[time.Time, float64] {
+	return ts.core().Backward()
+}
+
+// Collect builds a TimeSeries from a sequence of timestamp/value pairs,
+// e.g. the filtered or mapped output of another series' All.
+func Collect(seq iter.Seq2[time.Time, float64]) TimeSeries {
+	return TimeSeries(CollectSeries(seq))
+}
+
+// All yields every timestamp with its left and right values in order.
+func (ts *AlignedSeries) All() iter.Seq2[time.Time, Pair[float64, float64]] {
+	return func(yield func(time.Time, Pair[float64, float64]) bool) {
+		for _, dp := range ts.datapoints {
+			if !yield(dp.Timestamp, Pair[float64, float64]{Left: dp.LeftValue, Right: dp.RightValue}) {
+				return
+			}
+		}
+	}
+}
+
+// Points yields every aligned datapoint in order.
+func (ts *AlignedSeries) Points() iter.Seq[DoubleDataPoint] {
+	return func(yield func(DoubleDataPoint) bool) {
+		for _, dp := range ts.datapoints {
+			if !yield(dp) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields every timestamp with its left and right values from last to first.
+func (ts *AlignedSeries) Backward() iter.Seq2[time.Time, Pair[float64, float64]] {
+	return func(yield func(time.Time, Pair[float64, float64]) bool) {
+		for i := len(ts.datapoints) - 1; i >= 0; i-- {
+			dp := ts.datapoints[i]
+			if !yield(dp.Timestamp, Pair[float64, float64]{Left: dp.LeftValue, Right: dp.RightValue}) {
+				return
+			}
+		}
+	}
+}
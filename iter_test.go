@@ -0,0 +1,106 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIterators(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := EmptyLabeled("cpu")
+	for i, v := range []float64{1, 2, 3, 4} {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Minute), v})
+	}
+
+	total := 0.0
+	for ti, v := range ts.All() {
+		if !ti.Equal(base.Add(time.Duration(v-1) * time.Minute)) {
+			t.Errorf("timestamp %v does not match value %v", ti, v)
+		}
+		total += v
+	}
+	if total != 10 {
+		t.Errorf("expected total 10, got %v", total)
+	}
+
+	var backward []float64
+	for _, v := range ts.Backward() {
+		backward = append(backward, v)
+		if len(backward) == 3 {
+			break
+		}
+	}
+	if len(backward) != 3 || backward[0] != 4 || backward[2] != 2 {
+		t.Errorf("unexpected backward values %v", backward)
+	}
+
+	count := 0
+	for dp := range ts.Points() {
+		if dp.Value > 2 {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected 2 points above 2, got %d", count)
+	}
+}
+
+func TestCollectBuildsLazyPipeline(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := EmptyLabeled("cpu")
+	for i, v := range []float64{1, 5, 2, 8} {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Minute), v})
+	}
+
+	high := Collect(func(yield func(time.Time, float64) bool) {
+		for ti, v := range ts.All() {
+			if v > 4 && !yield(ti, v*10) {
+				return
+			}
+		}
+	})
+	expected := []float64{50, 80}
+	if high.Length() != len(expected) {
+		t.Fatalf("expected %d points, got %d", len(expected), high.Length())
+	}
+	for i, v := range high.Values() {
+		if v != expected[i] {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], v)
+		}
+	}
+}
+
+func TestAlignedSeriesIterators(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	left := Empty()
+	right := Empty()
+	for i := 0; i < 3; i++ {
+		left.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Hour), float64(i)})
+		right.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Hour), float64(10 * i)})
+	}
+	aligned := left.Join(right)
+
+	diff := 0.0
+	for _, p := range aligned.All() {
+		diff += p.Right - p.Left
+	}
+	if diff != 27 {
+		t.Errorf("expected summed difference 27, got %v", diff)
+	}
+
+	var first time.Time
+	for ti := range aligned.Backward() {
+		first = ti
+	}
+	if !first.Equal(base) {
+		t.Errorf("expected backward iteration to end at %v, got %v", base, first)
+	}
+
+	n := 0
+	for range aligned.Points() {
+		n++
+	}
+	if n != 3 {
+		t.Errorf("expected 3 aligned points, got %d", n)
+	}
+}
@@ -0,0 +1,82 @@
+package timeseriesgo
+
+import "time"
+
+// AsOfDirection selects which right-hand point JoinAsOf matches to a left-hand point.
+type AsOfDirection int
+
+const (
+	// AsOfBackward matches the last right point at or before the left timestamp (the last of equal ones).
+	AsOfBackward AsOfDirection = iota
+	// AsOfForward matches the first right point at or after the left timestamp.
+	AsOfForward
+	// AsOfNearest matches the closest right point; on a tie the earlier one wins.
+	AsOfNearest
+)
+
+/**
+ * Joins two sorted TimeSeries by nearest key instead of exact timestamp (like pandas merge_asof).
+ * Every left point is paired with at most one right point; left points without a match
+ * within tolerance are dropped, so the result can be fed straight into metrics.
+ *
+ * @param otherTS The right-hand series.
+ * @param direction Which right point to look for (backward, forward or nearest).
+ * @param tolerance Maximum distance between matched timestamps; a negative tolerance means no limit.
+ *
+ * @return An AlignedSeries stamped with the left timestamps.
+ */
+func (ts *TimeSeries) JoinAsOf(otherTS TimeSeries, direction AsOfDirection, tolerance time.Duration) AlignedSeries {
+	if ts.IsEmpty() || otherTS.IsEmpty() {
+		return alignedFrom(ts, "empty series")
+	}
+	res := alignedFrom(ts, ts.label+" joined with "+otherTS.label)
+	right := otherTS.times
+	tol := int64(tolerance)
+
+	j := 0
+	for i, left := range ts.times {
+		// j is the first right point at or after the left timestamp.
+		for j < len(right) && right[j] < left {
+			j++
+		}
+
+		match := -1
+		switch direction {
+		case AsOfBackward:
+			// The last right point at or before left, like AsOf: skip past equal keys.
+			k := j
+			for k < len(right) && right[k] == left {
+				k++
+			}
+			match = k - 1
+		case AsOfForward:
+			if j < len(right) {
+				match = j
+			}
+		case AsOfNearest:
+			if j < len(right) {
+				match = j
+			}
+			if j > 0 && (match < 0 || left-right[j-1] <= right[j]-left) {
+				match = j - 1
+			}
+		}
+		if match < 0 {
+			continue
+		}
+
+		dist := left - right[match]
+		if dist < 0 {
+			dist = -dist
+		}
+		if tolerance >= 0 && dist > tol {
+			continue
+		}
+		res.datapoints = append(res.datapoints, DoubleDataPoint{
+			Timestamp:  ts.core().timeAt(i),
+			LeftValue:  ts.values[i],
+			RightValue: otherTS.values[match],
+		})
+	}
+	return res
+}
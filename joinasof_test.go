@@ -0,0 +1,72 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJoinAsOf(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	left := Empty()
+	left.AddPoint(DataPoint{Timestamp: base.Add(1 * time.Second), Value: 1})
+	left.AddPoint(DataPoint{Timestamp: base.Add(5 * time.Second), Value: 2})
+	left.AddPoint(DataPoint{Timestamp: base.Add(10 * time.Second), Value: 3})
+
+	right := Empty()
+	right.AddPoint(DataPoint{Timestamp: base.Add(2 * time.Second), Value: 20})
+	right.AddPoint(DataPoint{Timestamp: base.Add(4 * time.Second), Value: 40})
+	right.AddPoint(DataPoint{Timestamp: base.Add(10 * time.Second), Value: 100})
+
+	cases := []struct {
+		name      string
+		direction AsOfDirection
+		tolerance time.Duration
+		expected  []DoubleDataPoint
+	}{
+		{"backward", AsOfBackward, -1, []DoubleDataPoint{
+			{Timestamp: base.Add(5 * time.Second), LeftValue: 2, RightValue: 40},
+			{Timestamp: base.Add(10 * time.Second), LeftValue: 3, RightValue: 100},
+		}},
+		{"forward", AsOfForward, -1, []DoubleDataPoint{
+			{Timestamp: base.Add(1 * time.Second), LeftValue: 1, RightValue: 20},
+			{Timestamp: base.Add(5 * time.Second), LeftValue: 2, RightValue: 100},
+			{Timestamp: base.Add(10 * time.Second), LeftValue: 3, RightValue: 100},
+		}},
+		{"nearest within tolerance", AsOfNearest, time.Second, []DoubleDataPoint{
+			{Timestamp: base.Add(1 * time.Second), LeftValue: 1, RightValue: 20},
+			{Timestamp: base.Add(5 * time.Second), LeftValue: 2, RightValue: 40},
+			{Timestamp: base.Add(10 * time.Second), LeftValue: 3, RightValue: 100},
+		}},
+		{"backward within tolerance", AsOfBackward, 500 * time.Millisecond, []DoubleDataPoint{
+			{Timestamp: base.Add(10 * time.Second), LeftValue: 3, RightValue: 100},
+		}},
+	}
+
+	for _, c := range cases {
+		joined := left.JoinAsOf(right, c.direction, c.tolerance)
+		if joined.Length() != len(c.expected) {
+			t.Fatalf("%s: expected %d points, got %d", c.name, len(c.expected), joined.Length())
+		}
+		for i, dp := range joined.DataPoints() {
+			exp := c.expected[i]
+			if !dp.Timestamp.Equal(exp.Timestamp) || dp.LeftValue != exp.LeftValue || dp.RightValue != exp.RightValue {
+				t.Errorf("%s idx %d: expected %+v, got %+v", c.name, i, exp, dp)
+			}
+		}
+	}
+}
+
+func TestJoinAsOfBackwardDuplicates(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	right := minuteSeries(base, []int{0, 1, 1, 2}, []float64{1, 2, 3, 4})
+	left := minuteSeries(base, []int{1}, []float64{0})
+	joined := left.JoinAsOf(right, AsOfBackward, -1)
+	pairs := joined.DataPoints()
+	if len(pairs) != 1 || pairs[0].RightValue != 3 {
+		t.Fatalf("expected the last duplicate 3, got %+v", pairs)
+	}
+	asOf, _ := right.AsOf(base.Add(time.Minute))
+	if asOf.Value != pairs[0].RightValue {
+		t.Errorf("JoinAsOf and AsOf disagree: %v vs %v", pairs[0].RightValue, asOf.Value)
+	}
+}
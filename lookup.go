@@ -0,0 +1,102 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by lookups that find no datapoint for the requested time.
+var ErrNotFound = errors.New("no datapoint found")
+
+// The lookups below binary search the datapoints and therefore expect a sorted series (see Validate).
+
+/**
+ * Returns the position of the datapoint with exactly the given timestamp, or -1.
+ */
+func (s *Series[T]) IndexOf(t time.Time) int {
+	i := s.searchIndex(t)
+	if i < len(s.times) && s.times[i] == t.UnixNano() {
+		return i
+	}
+	return -1
+}
+
+/**
+ * Returns the datapoint with exactly the given timestamp.
+ *
+ * @return The datapoint, or ErrNotFound.
+ */
+func (s *Series[T]) At(t time.Time) (Point[T], error) {
+	i := s.IndexOf(t)
+	if i < 0 {
+		return Point[T]{}, ErrNotFound
+	}
+	return s.pointAt(i), nil
+}
+
+/**
+ * Returns the last datapoint at or before t; with duplicate timestamps, the last of them.
+ *
+ * @return The datapoint, or ErrNotFound when t is before the first point.
+ */
+func (s *Series[T]) AsOf(t time.Time) (Point[T], error) {
+	// i is the first point after t.
+	i := s.searchIndex(t.Add(time.Nanosecond))
+	if i == 0 {
+		return Point[T]{}, ErrNotFound
+	}
+	return s.pointAt(i - 1), nil
+}
+
+/**
+ * Returns the datapoint closest to t. On a tie the earlier point wins.
+ *
+ * @param t The target time.
+ * @param tolerance Maximum allowed distance from t; a negative tolerance means no limit.
+ *
+ * @return The datapoint, or ErrNotFound when the series is empty or nothing is within tolerance.
+ */
+func (s *Series[T]) Nearest(t time.Time, tolerance time.Duration) (Point[T], error) {
+	i := s.searchIndex(t)
+	best := -1
+	var bestDist time.Duration
+	if i < len(s.times) {
+		best = i
+		bestDist = time.Duration(s.times[i] - t.UnixNano())
+	}
+	if i > 0 {
+		d := time.Duration(t.UnixNano() - s.times[i-1])
+		if best < 0 || d <= bestDist {
+			best = i - 1
+			bestDist = d
+		}
+	}
+	if best < 0 || (tolerance >= 0 && bestDist > tolerance) {
+		return Point[T]{}, ErrNotFound
+	}
+	return s.pointAt(best), nil
+}
+
+func (ts *TimeSeries) searchIndex(t time.Time) int {
+	return ts.core().searchIndex(t)
+}
+
+// IndexOf is Series.IndexOf for float64 values.
+func (ts *TimeSeries) IndexOf(t time.Time) int {
+	return ts.core().IndexOf(t)
+}
+
+// At is Series.At for float64 values.
+func (ts *TimeSeries) At(t time.Time) (DataPoint, error) {
+	return ts.core().At(t)
+}
+
+// AsOf is Series.AsOf for float64 values.
+func (ts *TimeSeries) AsOf(t time.Time) (DataPoint, error) {
+	return ts.core().AsOf(t)
+}
+
+// Nearest is Series.Nearest for float64 values.
+func (ts *TimeSeries) Nearest(t time.Time, tolerance time.Duration) (DataPoint, error) {
+	return ts.core().Nearest(t, tolerance)
+}
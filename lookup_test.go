@@ -0,0 +1,91 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func lookupSeries(base time.Time) TimeSeries {
+	ts := Empty()
+	ts.AddPoint(DataPoint{Timestamp: base, Value: 1})
+	ts.AddPoint(DataPoint{Timestamp: base.Add(10 * time.Minute), Value: 2})
+	ts.AddPoint(DataPoint{Timestamp: base.Add(20 * time.Minute), Value: 3})
+	return ts
+}
+
+func TestAtAndIndexOf(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := lookupSeries(base)
+
+	if idx := ts.IndexOf(base.Add(10 * time.Minute)); idx != 1 {
+		t.Errorf("expected index 1, got %d", idx)
+	}
+	if idx := ts.IndexOf(base.Add(5 * time.Minute)); idx != -1 {
+		t.Errorf("expected index -1 for missing timestamp, got %d", idx)
+	}
+
+	dp, err := ts.At(base.Add(20 * time.Minute))
+	if err != nil || dp.Value != 3 {
+		t.Errorf("expected value 3, got %v (err %v)", dp.Value, err)
+	}
+	if _, err := ts.At(base.Add(time.Minute)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := lookupSeries(base)
+
+	cases := []struct {
+		at       time.Time
+		expected float64
+	}{
+		{base, 1},
+		{base.Add(9 * time.Minute), 1},
+		{base.Add(10 * time.Minute), 2},
+		{base.Add(time.Hour), 3},
+	}
+	for _, c := range cases {
+		dp, err := ts.AsOf(c.at)
+		if err != nil || dp.Value != c.expected {
+			t.Errorf("AsOf(%v): expected %v, got %v (err %v)", c.at, c.expected, dp.Value, err)
+		}
+	}
+
+	if _, err := ts.AsOf(base.Add(-time.Second)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound before first point, got %v", err)
+	}
+}
+
+func TestAsOfDuplicates(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 1, 2}, []float64{1, 2, 3, 4})
+	for _, at := range []time.Time{base.Add(time.Minute), base.Add(90 * time.Second)} {
+		if dp, err := ts.AsOf(at); err != nil || dp.Value != 3 {
+			t.Errorf("AsOf(%v): expected the last duplicate 3, got %v (err %v)", at, dp.Value, err)
+		}
+	}
+}
+
+func TestNearest(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := lookupSeries(base)
+
+	dp, err := ts.Nearest(base.Add(14*time.Minute), -1)
+	if err != nil || dp.Value != 2 {
+		t.Errorf("expected nearest value 2, got %v (err %v)", dp.Value, err)
+	}
+	dp, err = ts.Nearest(base.Add(16*time.Minute), time.Minute*5)
+	if err != nil || dp.Value != 3 {
+		t.Errorf("expected nearest value 3, got %v (err %v)", dp.Value, err)
+	}
+	dp, err = ts.Nearest(base.Add(15*time.Minute), time.Minute*5)
+	if err != nil || dp.Value != 2 {
+		t.Errorf("expected tie to pick earlier value 2, got %v (err %v)", dp.Value, err)
+	}
+	if _, err := ts.Nearest(base.Add(time.Hour), time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound outside tolerance, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package timeseriesgo
+
+import "maps"
+
+// Metadata describes what a series measures. Transforms of a single series keep
+// the metadata of their input; joins name the result after both inputs.
+type Metadata struct {
+	Name string
+	Tags map[string]string
+	Unit string
+}
+
+// Label returns the series name.
+func (s *Series[T]) Label() string {
+	return s.label
+}
+
+// SetLabel renames the series.
+func (s *Series[T]) SetLabel(label string) {
+	s.label = label
+}
+
+// Unit returns the unit of the values, or "" when none was set.
+func (s *Series[T]) Unit() string {
+	return s.unit
+}
+
+// SetUnit sets the unit of the values, e.g. "kW" or "bytes".
+func (s *Series[T]) SetUnit(unit string) {
+	s.unit = unit
+}
+
+// Tag returns the value of a tag and whether it is set.
+func (s *Series[T]) Tag(key string) (string, bool) {
+	v, ok := s.tags[key]
+	return v, ok
+}
+
+// SetTag sets a key/value tag such as host=web1.
+func (s *Series[T]) SetTag(key, value string) {
+	if s.tags == nil {
+		s.tags = make(map[string]string)
+	}
+	s.tags[key] = value
+}
+
+// Tags returns a copy of all tags.
+func (s *Series[T]) Tags() map[string]string {
+	if s.tags == nil {
+		return map[string]string{}
+	}
+	return maps.Clone(s.tags)
+}
+
+// Metadata returns a copy of name, tags and unit.
+func (s *Series[T]) Metadata() Metadata {
+	return Metadata{Name: s.label, Tags: s.Tags(), Unit: s.unit}
+}
+
+// SetMetadata replaces name, tags and unit. The tags map is copied.
+func (s *Series[T]) SetMetadata(m Metadata) {
+	s.label = m.Name
+	s.tags = maps.Clone(m.Tags)
+	s.unit = m.Unit
+}
+
+// The TimeSeries accessors below forward to the generic Series implementation.
+
+func (ts *TimeSeries) Label() string {
+	return ts.core().Label()
+}
+
+func (ts *TimeSeries) SetLabel(label string) {
+	ts.core().SetLabel(label)
+}
+
+func (ts *TimeSeries) Unit() string {
+	return ts.core().Unit()
+}
+
+func (ts *TimeSeries) SetUnit(unit string) {
+	ts.core().SetUnit(unit)
+}
+
+func (ts *TimeSeries) Tag(key string) (string, bool) {
+	return ts.core().Tag(key)
+}
+
+func (ts *TimeSeries) SetTag(key, value string) {
+	ts.core().SetTag(key, value)
+}
+
+func (ts *TimeSeries) Tags() map[string]string {
+	return ts.core().Tags()
+}
+
+func (ts *TimeSeries) Metadata() Metadata {
+	return ts.core().Metadata()
+}
+
+func (ts *TimeSeries) SetMetadata(m Metadata) {
+	ts.core().SetMetadata(m)
+}
+
+func (ts *TimeSeries) derive() TimeSeries {
+	return TimeSeries(ts.core().derive())
+}
+
+func (ts *TimeSeries) appendRaw(t int64, v float64) {
+	ts.core().appendRaw(t, v)
+}
+
+func (ts *TimeSeries) withPoints(points []DataPoint) TimeSeries {
+	res := ts.derive()
+	for _, dp := range points {
+		res.AddPoint(dp)
+	}
+	return res
+}
+
+// Label returns the name of the aligned series.
+func (ts *AlignedSeries) Label() string {
+	return ts.label
+}
+
+// SetLabel renames the aligned series.
+func (ts *AlignedSeries) SetLabel(label string) {
+	ts.label = label
+}
+
+// Label returns the name of the frame.
+func (ms *MultiSeries) Label() string {
+	return ms.label
+}
+
+// SetLabel renames the frame.
+func (ms *MultiSeries) SetLabel(label string) {
+	ms.label = label
+}
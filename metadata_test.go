@@ -0,0 +1,121 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadataAccessors(t *testing.T) {
+	ts := EmptyLabeled("cpu")
+	ts.SetTag("host", "web1")
+	ts.SetUnit("%")
+
+	if ts.Label() != "cpu" {
+		t.Errorf("expected label cpu, got %q", ts.Label())
+	}
+	if v, ok := ts.Tag("host"); !ok || v != "web1" {
+		t.Errorf("expected host tag web1, got %q (%v)", v, ok)
+	}
+
+	tags := ts.Tags()
+	tags["host"] = "changed"
+	if v, _ := ts.Tag("host"); v != "web1" {
+		t.Errorf("Tags must return a copy, tag changed to %q", v)
+	}
+
+	ts.SetLabel("load")
+	m := ts.Metadata()
+	if m.Name != "load" || m.Unit != "%" || m.Tags["host"] != "web1" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+}
+
+func TestTransformsPropagateMetadata(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := EmptyLabeled("power")
+	ts.SetTag("site", "north")
+	ts.SetUnit("kW")
+	ts.AddPoint(DataPoint{base, 1})
+	ts.AddPoint(DataPoint{base.Add(time.Hour), 2})
+	ts.AddPoint(DataPoint{base.Add(3 * time.Hour), 4})
+
+	results := map[string]TimeSeries{
+		"Tail":      ts.Tail(),
+		"Slice":     ts.Slice(base, base.Add(2*time.Hour)),
+		"MapValues": ts.MapValues(increment),
+		"Map":       ts.Map(func(dp DataPoint) DataPoint { return dp }),
+		"Filter":    ts.Filter(greaterThan15),
+		"Merge":     ts.Merge(Empty()),
+		"Resample":  ts.ResampleWithDefaultValue(time.Hour, 0),
+		"Step":      ts.Step(time.Hour),
+		"GroupBy":   ts.GroupByTime(roundToHour, sum),
+	}
+	for name, res := range results {
+		m := res.Metadata()
+		if m.Name != "power" || m.Unit != "kW" || m.Tags["site"] != "north" {
+			t.Errorf("%s dropped metadata: %+v", name, m)
+		}
+	}
+
+	derived := ts.Tail()
+	derived.SetTag("site", "south")
+	if v, _ := ts.Tag("site"); v != "north" {
+		t.Errorf("derived series must not share tags with its source")
+	}
+}
+
+func TestJoinsPropagateMetadata(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := EmptyLabeled("errors")
+	a.SetTag("host", "a")
+	a.SetUnit("req")
+	a.AddPoint(DataPoint{base, 1})
+	a.AddPoint(DataPoint{base.Add(time.Hour), 2})
+	b := EmptyLabeled("requests")
+	b.SetTag("host", "b")
+	b.AddPoint(DataPoint{base, 10})
+
+	frame, err := JoinAll([]string{"x", "y"}, []TimeSeries{a, b}, OuterJoin, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, _ := frame.Column("x")
+	if m := x.Metadata(); m.Name != "x" || m.Tags["host"] != "a" || m.Unit != "req" {
+		t.Errorf("Column dropped metadata: %+v", m)
+	}
+	split := frame.ToTimeSeries()
+	if v, _ := split[1].Tag("host"); v != "b" {
+		t.Errorf("ToTimeSeries dropped tags: %+v", split[1].Metadata())
+	}
+	selected, _ := frame.Select("y")
+	if m, _ := selected.ColumnMetadata("y"); m.Tags["host"] != "b" {
+		t.Errorf("Select dropped tags: %+v", m)
+	}
+	x.SetTag("host", "changed")
+	if m, _ := frame.ColumnMetadata("x"); m.Tags["host"] != "a" {
+		t.Errorf("columns must not share tags with the frame")
+	}
+
+	ohlc := a.OHLC(Every(time.Hour), ResampleOptions{})
+	if open, _ := ohlc.Column("open"); open.Unit() != "req" {
+		t.Errorf("OHLC dropped the unit: %+v", open.Metadata())
+	}
+
+	for name, aligned := range map[string]AlignedSeries{
+		"Join":      a.Join(b),
+		"JoinLeft":  a.JoinLeft(b, 0),
+		"JoinOuter": a.JoinOuter(b, 0, 0),
+		"JoinAsOf":  a.JoinAsOf(b, AsOfBackward, -1),
+	} {
+		ratio := aligned.MapValuesWithReduce(func(l, r float64) float64 { return l / r })
+		m := ratio.Metadata()
+		if m.Name != "errors joined with requests" || m.Tags["host"] != "a" || m.Unit != "req" {
+			t.Errorf("%s dropped metadata: %+v", name, m)
+		}
+		aligned.SetLabel("ratio")
+		renamed := aligned.MapValuesWithReduce(func(l, r float64) float64 { return l / r })
+		if m := renamed.Metadata(); m.Name != "ratio" || m.Tags["host"] != "a" {
+			t.Errorf("%s ignored SetLabel: %+v", name, m)
+		}
+	}
+}
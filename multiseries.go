@@ -0,0 +1,295 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// JoinKind selects which timestamps survive an N-way alignment.
+type JoinKind int
+
+const (
+	// InnerJoin keeps timestamps present in every series.
+	InnerJoin JoinKind = iota
+	// LeftJoin keeps the timestamps of the first series.
+	LeftJoin
+	// OuterJoin keeps timestamps present in any series.
+	OuterJoin
+)
+
+// MultiSeries holds several named value columns on one shared timestamp index.
+// It generalises AlignedSeries from two values per timestamp to any number.
+type MultiSeries struct {
+	timestamps []int64
+	loc        *time.Location
+	columns    []string
+	values     [][]float64
+	label      string
+	// meta holds the metadata of each column; Name is the column name.
+	meta []Metadata
+}
+
+/**
+ * Aligns several sorted TimeSeries on their timestamps in a single merge pass.
+ *
+ * @param columns Column names, one per series; names must be unique.
+ * @param series The series to align.
+ * @param kind InnerJoin, LeftJoin or OuterJoin.
+ * @param fill Value used for columns that have no point at a kept timestamp.
+ *
+ * @return A MultiSeries in chronological order, or an error for mismatched or duplicate column names
+ *         or an unsupported kind. Each column keeps the tags and unit of its series.
+ */
+func JoinAll(columns []string, series []TimeSeries, kind JoinKind, fill float64) (MultiSeries, error) {
+	if kind != InnerJoin && kind != LeftJoin && kind != OuterJoin {
+		return MultiSeries{}, fmt.Errorf("unsupported join kind %d", kind)
+	}
+	if len(columns) != len(series) {
+		return MultiSeries{}, errors.New("columns and series slices must have the same length")
+	}
+	if len(series) == 0 {
+		return MultiSeries{}, errors.New("at least one series is required")
+	}
+	if err := checkColumnNames(columns); err != nil {
+		return MultiSeries{}, err
+	}
+
+	res := MultiSeries{
+		columns: append([]string(nil), columns...),
+		values:  make([][]float64, len(series)),
+		label:   strings.Join(columns, ", "),
+		loc:     series[0].loc,
+		meta:    make([]Metadata, len(series)),
+	}
+	for k := range series {
+		res.meta[k] = series[k].Metadata()
+		res.meta[k].Name = columns[k]
+	}
+	pos := make([]int, len(series))
+	row := make([]float64, len(series))
+	present := make([]bool, len(series))
+
+	for {
+		// The next timestamp is the smallest head across all series.
+		var next int64
+		found := false
+		for k, s := range series {
+			if pos[k] < len(s.times) {
+				t := s.times[pos[k]]
+				if !found || t < next {
+					next = t
+					found = true
+				}
+			}
+		}
+		if !found {
+			break
+		}
+
+		count := 0
+		for k, s := range series {
+			present[k] = pos[k] < len(s.times) && s.times[pos[k]] == next
+			if present[k] {
+				row[k] = s.values[pos[k]]
+				pos[k]++
+				count++
+			} else {
+				row[k] = fill
+			}
+		}
+
+		keep := false
+		switch kind {
+		case InnerJoin:
+			keep = count == len(series)
+		case LeftJoin:
+			keep = present[0]
+		case OuterJoin:
+			keep = true
+		}
+		if keep {
+			res.timestamps = append(res.timestamps, next)
+			for k := range row {
+				res.values[k] = append(res.values[k], row[k])
+			}
+		}
+	}
+	return res, nil
+}
+
+func checkColumnNames(columns []string) error {
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c] {
+			return fmt.Errorf("duplicate column name %q", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+func (ms *MultiSeries) Length() int {
+	return len(ms.timestamps)
+}
+
+func (ms *MultiSeries) IsEmpty() bool {
+	return len(ms.timestamps) == 0
+}
+
+// Columns returns a copy of the column names in order.
+func (ms *MultiSeries) Columns() []string {
+	return append([]string(nil), ms.columns...)
+}
+
+// Timestamps returns a copy of the shared index.
+func (ms *MultiSeries) Timestamps() []time.Time {
+	res := make([]time.Time, len(ms.timestamps))
+	for i := range ms.timestamps {
+		res[i] = ms.timeAt(i)
+	}
+	return res
+}
+
+func (ms *MultiSeries) timeAt(i int) time.Time {
+	loc := ms.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Unix(0, ms.timestamps[i]).In(loc)
+}
+
+func (ms *MultiSeries) columnIndex(name string) (int, error) {
+	for i, c := range ms.columns {
+		if c == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown column %q", name)
+}
+
+/**
+ * Returns one column as a TimeSeries labelled with the column name, with the tags and unit of the column.
+ */
+func (ms *MultiSeries) Column(name string) (TimeSeries, error) {
+	k, err := ms.columnIndex(name)
+	if err != nil {
+		return Empty(), err
+	}
+	return ms.columnSeries(k), nil
+}
+
+// ColumnMetadata returns the metadata of one column; Name is the column name.
+func (ms *MultiSeries) ColumnMetadata(name string) (Metadata, error) {
+	k, err := ms.columnIndex(name)
+	if err != nil {
+		return Metadata{}, err
+	}
+	return ms.columnMetadata(k), nil
+}
+
+func (ms *MultiSeries) columnMetadata(k int) Metadata {
+	if k < len(ms.meta) {
+		m := ms.meta[k]
+		m.Tags = maps.Clone(m.Tags)
+		return m
+	}
+	return Metadata{Name: ms.columns[k]}
+}
+
+func (ms *MultiSeries) columnSeries(k int) TimeSeries {
+	res := EmptyLabeled(ms.columns[k])
+	res.SetMetadata(ms.columnMetadata(k))
+	res.times = append(res.times, ms.timestamps...)
+	res.values = append(res.values, ms.values[k]...)
+	res.loc = ms.loc
+	return res
+}
+
+/**
+ * Returns a MultiSeries with only the named columns, in the given order.
+ */
+func (ms *MultiSeries) Select(names ...string) (MultiSeries, error) {
+	if err := checkColumnNames(names); err != nil {
+		return MultiSeries{}, err
+	}
+	res := MultiSeries{
+		timestamps: append([]int64(nil), ms.timestamps...),
+		loc:        ms.loc,
+		columns:    append([]string(nil), names...),
+		values:     make([][]float64, len(names)),
+		label:      ms.label,
+		meta:       make([]Metadata, len(names)),
+	}
+	for i, name := range names {
+		k, err := ms.columnIndex(name)
+		if err != nil {
+			return MultiSeries{}, err
+		}
+		res.values[i] = append([]float64(nil), ms.values[k]...)
+		res.meta[i] = ms.columnMetadata(k)
+	}
+	return res, nil
+}
+
+/**
+ * Returns the values of row i in column order.
+ */
+func (ms *MultiSeries) Row(i int) (time.Time, []float64, error) {
+	if i < 0 || i >= len(ms.timestamps) {
+		return time.Time{}, nil, fmt.Errorf("row %d out of range", i)
+	}
+	row := make([]float64, len(ms.columns))
+	for k := range ms.columns {
+		row[k] = ms.values[k][i]
+	}
+	return ms.timeAt(i), row, nil
+}
+
+/**
+ * Reduces every row to a single value, e.g. the mean across servers.
+ *
+ * @param f Receives the row values in column order. The slice is reused between calls.
+ *
+ * @return A TimeSeries on the shared index.
+ */
+func (ms *MultiSeries) MapRowsWithReduce(f func([]float64) float64) TimeSeries {
+	mapped := EmptyLabeled(ms.label)
+	mapped.loc = ms.loc
+	row := make([]float64, len(ms.columns))
+	for i, t := range ms.timestamps {
+		for k := range ms.columns {
+			row[k] = ms.values[k][i]
+		}
+		mapped.appendRaw(t, f(row))
+	}
+	return mapped
+}
+
+/**
+ * Splits the frame back into one TimeSeries per column.
+ */
+func (ms *MultiSeries) ToTimeSeries() []TimeSeries {
+	res := make([]TimeSeries, len(ms.columns))
+	for k := range ms.columns {
+		res[k] = ms.columnSeries(k)
+	}
+	return res
+}
+
+/**
+ * Prints the MultiSeries in a human-readable format.
+ */
+func (ms *MultiSeries) Print() {
+	fmt.Println("Timestamp, " + strings.Join(ms.columns, ", "))
+	for i := range ms.timestamps {
+		var sb strings.Builder
+		sb.WriteString(ms.timeAt(i).Format(time.RFC3339))
+		for k := range ms.columns {
+			fmt.Fprintf(&sb, ", %.2f", ms.values[k][i])
+		}
+		fmt.Println(sb.String())
+	}
+}
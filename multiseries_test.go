@@ -0,0 +1,115 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func multiSeriesInputs(base time.Time) []TimeSeries {
+	a := EmptyLabeled("a")
+	a.AddPoint(DataPoint{base, 1})
+	a.AddPoint(DataPoint{base.Add(time.Hour), 2})
+	a.AddPoint(DataPoint{base.Add(2 * time.Hour), 3})
+
+	b := EmptyLabeled("b")
+	b.AddPoint(DataPoint{base.Add(time.Hour), 20})
+	b.AddPoint(DataPoint{base.Add(2 * time.Hour), 30})
+	b.AddPoint(DataPoint{base.Add(3 * time.Hour), 40})
+
+	c := EmptyLabeled("c")
+	c.AddPoint(DataPoint{base.Add(2 * time.Hour), 300})
+	return []TimeSeries{a, b, c}
+}
+
+func TestJoinAllKinds(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	series := multiSeriesInputs(base)
+	columns := []string{"a", "b", "c"}
+
+	cases := []struct {
+		kind     JoinKind
+		expected int
+	}{
+		{InnerJoin, 1},
+		{LeftJoin, 3},
+		{OuterJoin, 4},
+	}
+	for _, c := range cases {
+		ms, err := JoinAll(columns, series, c.kind, -1)
+		if err != nil {
+			t.Fatalf("kind %d: unexpected error: %v", c.kind, err)
+		}
+		if ms.Length() != c.expected {
+			t.Errorf("kind %d: expected %d rows, got %d", c.kind, c.expected, ms.Length())
+		}
+	}
+
+	outer, _ := JoinAll(columns, series, OuterJoin, -1)
+	ts, row, err := outer.Row(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ts.Equal(base.Add(time.Hour)) || row[0] != 2 || row[1] != 20 || row[2] != -1 {
+		t.Errorf("unexpected row 1: %v %v", ts, row)
+	}
+}
+
+func TestJoinAllRejectsDuplicateColumns(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	series := multiSeriesInputs(base)
+	if _, err := JoinAll([]string{"a", "a", "c"}, series, InnerJoin, 0); err == nil {
+		t.Errorf("expected error for duplicate column names")
+	}
+	if _, err := JoinAll([]string{"a"}, series, InnerJoin, 0); err == nil {
+		t.Errorf("expected error for mismatched lengths")
+	}
+}
+
+func TestJoinAllRejectsUnknownKind(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	series := multiSeriesInputs(base)
+	for _, n := range []int{0, 1, len(series)} {
+		if _, err := JoinAll([]string{"a", "b", "c"}[:n], series[:n], JoinKind(42), 0); err == nil {
+			t.Errorf("expected error for an unknown join kind with %d series", n)
+		}
+	}
+}
+
+func TestMultiSeriesSelectReduceAndSplit(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ms, _ := JoinAll([]string{"a", "b", "c"}, multiSeriesInputs(base), OuterJoin, 0)
+
+	selected, err := ms.Select("b", "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cols := selected.Columns(); len(cols) != 2 || cols[0] != "b" || cols[1] != "a" {
+		t.Errorf("unexpected columns %v", cols)
+	}
+	if _, err := ms.Select("missing"); err == nil {
+		t.Errorf("expected error for unknown column")
+	}
+
+	sums := ms.MapRowsWithReduce(func(row []float64) float64 {
+		total := 0.0
+		for _, v := range row {
+			total += v
+		}
+		return total
+	})
+	expected := []float64{1, 22, 333, 40}
+	for i, v := range sums.Values() {
+		if v != expected[i] {
+			t.Errorf("row %d expected sum %v, got %v", i, expected[i], v)
+		}
+	}
+
+	parts := ms.ToTimeSeries()
+	if len(parts) != 3 || parts[1].Length() != 4 {
+		t.Fatalf("expected 3 columns of 4 points")
+	}
+	b, err := ms.Column("b")
+	if err != nil || b.Values()[3] != 40 {
+		t.Errorf("unexpected column b: %v (err %v)", b.Values(), err)
+	}
+}
@@ -0,0 +1,190 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DuplicatePolicy decides how datapoints sharing a timestamp are collapsed into one.
+type DuplicatePolicy int
+
+const (
+	// KeepFirst keeps the first datapoint added for a timestamp.
+	KeepFirst DuplicatePolicy = iota
+	// KeepLast keeps the last datapoint added for a timestamp.
+	KeepLast
+	// SumDuplicates replaces duplicates with the sum of their values.
+	SumDuplicates
+	// MeanDuplicates replaces duplicates with the mean of their values.
+	MeanDuplicates
+	// RejectDuplicates makes construction fail with ErrDuplicateTimestamp.
+	RejectDuplicates
+)
+
+var (
+	// ErrUnsorted is reported when a datapoint is earlier than its predecessor.
+	ErrUnsorted = errors.New("datapoints are not in chronological order")
+	// ErrDuplicateTimestamp is reported when two datapoints share a timestamp.
+	ErrDuplicateTimestamp = errors.New("duplicate timestamp")
+)
+
+// ValidationError points at the first datapoint that breaks the ordering guarantee.
+// Index is always a position in the caller's order: in the series for Validate, in the
+// order of Add/AddAll calls for Builder.Build.
+// Use errors.Is with ErrUnsorted or ErrDuplicateTimestamp to inspect the cause.
+type ValidationError struct {
+	Index     int
+	Timestamp time.Time
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("datapoint %d at %s: %v", e.Index, e.Timestamp.Format(time.RFC3339Nano), e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+/**
+ * Reports whether timestamps never decrease. Duplicates are allowed.
+ */
+func (ts *TimeSeries) IsSorted() bool {
+	for i := 1; i < len(ts.times); i++ {
+		if ts.times[i] < ts.times[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+/**
+ * Checks that timestamps are strictly increasing, which is what ordering dependent
+ * operations (Merge, Slice, Resample, Step, joins, forecasters) assume.
+ *
+ * @return nil for a valid series, otherwise a *ValidationError for the first offending point.
+ */
+func (ts *TimeSeries) Validate() error {
+	for i := 1; i < len(ts.times); i++ {
+		if ts.times[i] < ts.times[i-1] {
+			return &ValidationError{Index: i, Timestamp: ts.core().timeAt(i), Err: ErrUnsorted}
+		}
+		if ts.times[i] == ts.times[i-1] {
+			return &ValidationError{Index: i, Timestamp: ts.core().timeAt(i), Err: ErrDuplicateTimestamp}
+		}
+	}
+	return nil
+}
+
+/**
+ * Returns a sorted copy of the series with duplicates collapsed according to the policy.
+ *
+ * @param policy How to collapse datapoints with equal timestamps.
+ *
+ * @return A series that passes Validate, or an error if the policy is RejectDuplicates and duplicates exist.
+ */
+func (ts *TimeSeries) Normalize(policy DuplicatePolicy) (TimeSeries, error) {
+	res, err := NewBuilder(ts.label).WithDuplicatePolicy(policy).AddAll(ts.DataPoints()).Build()
+	res.SetMetadata(ts.Metadata())
+	return res, err
+}
+
+// Builder collects datapoints in any order and produces a validated TimeSeries.
+type Builder struct {
+	points []DataPoint
+	label  string
+	policy DuplicatePolicy
+}
+
+// NewBuilder returns a Builder using the KeepLast duplicate policy.
+func NewBuilder(label string) *Builder {
+	return &Builder{label: label, policy: KeepLast}
+}
+
+// WithDuplicatePolicy sets how datapoints sharing a timestamp are collapsed.
+func (b *Builder) WithDuplicatePolicy(policy DuplicatePolicy) *Builder {
+	b.policy = policy
+	return b
+}
+
+// Add appends a single datapoint.
+func (b *Builder) Add(dp DataPoint) *Builder {
+	b.points = append(b.points, dp)
+	return b
+}
+
+// AddAll appends all given datapoints.
+func (b *Builder) AddAll(points []DataPoint) *Builder {
+	b.points = append(b.points, points...)
+	return b
+}
+
+// Len returns the number of datapoints added so far, duplicates included.
+func (b *Builder) Len() int {
+	return len(b.points)
+}
+
+/**
+ * Sorts the collected datapoints (stable, so insertion order decides among duplicates)
+ * and collapses duplicates. The builder can be reused afterwards.
+ *
+ * @return A series with strictly increasing timestamps, or a *ValidationError wrapping
+ *         ErrDuplicateTimestamp when the policy is RejectDuplicates. Its Index is the insertion
+ *         position of the second datapoint added with the earliest duplicated timestamp.
+ */
+func (b *Builder) Build() (TimeSeries, error) {
+	// Sort insertion indices rather than points, so errors can name the caller's position.
+	order := make([]int, len(b.points))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		return b.points[i].Timestamp.Compare(b.points[j].Timestamp)
+	})
+	points := make([]DataPoint, len(order))
+	for k, i := range order {
+		points[k] = b.points[i]
+	}
+
+	result := EmptyLabeled(b.label)
+	for start := 0; start < len(points); {
+		end := start + 1
+		for end < len(points) && points[end].Timestamp.Equal(points[start].Timestamp) {
+			end++
+		}
+		dp, err := collapseDuplicates(points[start:end], b.policy)
+		if err != nil {
+			return EmptyLabeled(b.label), &ValidationError{Index: order[start+1], Timestamp: points[start].Timestamp, Err: err}
+		}
+		result.AddPoint(dp)
+		start = end
+	}
+	return result, nil
+}
+
+func collapseDuplicates(group []DataPoint, policy DuplicatePolicy) (DataPoint, error) {
+	if len(group) == 1 {
+		return group[0], nil
+	}
+	switch policy {
+	case KeepFirst:
+		return group[0], nil
+	case KeepLast:
+		return group[len(group)-1], nil
+	case SumDuplicates, MeanDuplicates:
+		total := 0.0
+		for _, dp := range group {
+			total += dp.Value
+		}
+		if policy == MeanDuplicates {
+			total /= float64(len(group))
+		}
+		return DataPoint{Timestamp: group[0].Timestamp, Value: total}, nil
+	case RejectDuplicates:
+		return DataPoint{}, ErrDuplicateTimestamp
+	default:
+		return DataPoint{}, fmt.Errorf("unknown duplicate policy %d", policy)
+	}
+}
@@ -0,0 +1,113 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBuilderSortsAndCollapsesDuplicates(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	points := []DataPoint{
+		{Timestamp: base.Add(2 * time.Hour), Value: 3},
+		{Timestamp: base, Value: 1},
+		{Timestamp: base.Add(time.Hour), Value: 2},
+		{Timestamp: base, Value: 5},
+	}
+
+	cases := []struct {
+		policy   DuplicatePolicy
+		expected []float64
+	}{
+		{KeepFirst, []float64{1, 2, 3}},
+		{KeepLast, []float64{5, 2, 3}},
+		{SumDuplicates, []float64{6, 2, 3}},
+		{MeanDuplicates, []float64{3, 2, 3}},
+	}
+
+	for _, c := range cases {
+		ts, err := NewBuilder("cpu").WithDuplicatePolicy(c.policy).AddAll(points).Build()
+		if err != nil {
+			t.Fatalf("policy %d: unexpected error: %v", c.policy, err)
+		}
+		if err := ts.Validate(); err != nil {
+			t.Errorf("policy %d: built series should be valid, got %v", c.policy, err)
+		}
+		vs := ts.Values()
+		if len(vs) != len(c.expected) {
+			t.Fatalf("policy %d: expected %d points, got %d", c.policy, len(c.expected), len(vs))
+		}
+		for i := range vs {
+			if vs[i] != c.expected[i] {
+				t.Errorf("policy %d idx %d: expected %v, got %v", c.policy, i, c.expected[i], vs[i])
+			}
+		}
+	}
+}
+
+func TestBuilderRejectDuplicates(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewBuilder("cpu").
+		WithDuplicatePolicy(RejectDuplicates).
+		Add(DataPoint{Timestamp: base, Value: 1}).
+		Add(DataPoint{Timestamp: base, Value: 2}).
+		Build()
+
+	if !errors.Is(err, ErrDuplicateTimestamp) {
+		t.Fatalf("expected ErrDuplicateTimestamp, got %v", err)
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !verr.Timestamp.Equal(base) {
+		t.Errorf("expected ValidationError at %v, got %v", base, err)
+	}
+}
+
+func TestBuilderErrorIndexIsInsertionOrder(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewBuilder("cpu").
+		WithDuplicatePolicy(RejectDuplicates).
+		Add(DataPoint{Timestamp: base.Add(time.Hour), Value: 1}).
+		Add(DataPoint{Timestamp: base, Value: 2}).
+		Add(DataPoint{Timestamp: base.Add(2 * time.Hour), Value: 3}).
+		Add(DataPoint{Timestamp: base.Add(time.Hour), Value: 4}).
+		Build()
+
+	// Sorted, the duplicate would sit at position 2; it was added at position 3.
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Index != 3 || !verr.Timestamp.Equal(base.Add(time.Hour)) {
+		t.Errorf("expected the duplicate added at index 3, got %v", err)
+	}
+}
+
+func TestValidateReportsFirstProblem(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	ts.AddPoint(DataPoint{Timestamp: base, Value: 1})
+	ts.AddPoint(DataPoint{Timestamp: base.Add(time.Hour), Value: 2})
+	ts.AddPoint(DataPoint{Timestamp: base.Add(time.Hour), Value: 3})
+	ts.AddPoint(DataPoint{Timestamp: base.Add(time.Minute), Value: 4})
+
+	if ts.IsSorted() {
+		t.Errorf("series with a late point should not be sorted")
+	}
+
+	err := ts.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if verr.Index != 2 || !errors.Is(err, ErrDuplicateTimestamp) {
+		t.Errorf("expected duplicate at index 2, got %v", err)
+	}
+
+	normalized, err := ts.Normalize(KeepFirst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []float64{1, 4, 2}
+	for i, v := range normalized.Values() {
+		if v != expected[i] {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], v)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package timeseriesgo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period is a calendar bucket used by GroupByPeriod.
+type Period int
+
+const (
+	PeriodHour Period = iota
+	PeriodDay
+	// PeriodISOWeek starts on Monday, as ISO 8601 weeks do.
+	PeriodISOWeek
+	PeriodMonth
+	PeriodQuarter
+	PeriodYear
+)
+
+func (p Period) String() string {
+	switch p {
+	case PeriodHour:
+		return "hour"
+	case PeriodDay:
+		return "day"
+	case PeriodISOWeek:
+		return "week"
+	case PeriodMonth:
+		return "month"
+	case PeriodQuarter:
+		return "quarter"
+	case PeriodYear:
+		return "year"
+	}
+	return fmt.Sprintf("Period(%d)", int(p))
+}
+
+/**
+ * Returns the start of the period holding t, on the wall clock of loc.
+ * Days start at local midnight, so a DST day lasts 23 or 25 hours. Hours are cut on
+ * the local clock too; the two 01:00 hours of an autumn DST change stay separate.
+ */
+func (p Period) Start(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch p {
+	case PeriodHour:
+		return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	case PeriodISOWeek:
+		return Weeks(1, time.Monday).Floor(t)
+	case PeriodMonth:
+		return MonthStart(1).Floor(t)
+	case PeriodQuarter:
+		return QuarterStart(1).Floor(t)
+	case PeriodYear:
+		return YearStart(1).Floor(t)
+	}
+	return Days(1).Floor(t)
+}
+
+/**
+ * Groups the TimeSeries into calendar periods of loc and reduces each with agg.
+ * Groups are found through a map keyed on the period start, so the cost is linear.
+ *
+ * @param period Hour, day, ISO week, month, quarter or year.
+ * @param loc The location whose wall clock defines the periods; nil uses the series location.
+ * @param agg Reduces the values of one period, e.g. AggregateSum or AggregatePercentile(95).
+ *
+ * @return A TimeSeries stamped with the period starts (in loc), in order of first appearance.
+ */
+func (ts *TimeSeries) GroupByPeriod(period Period, loc *time.Location, agg Aggregator) TimeSeries {
+	if loc == nil {
+		loc = ts.core().Location()
+	}
+	res := ts.derive()
+	res.loc = loc
+	var keys []int64
+	var groups [][]float64
+	index := make(map[int64]int)
+	for i, v := range ts.values {
+		key := period.Start(ts.core().timeAt(i), loc).UnixNano()
+		idx, ok := index[key]
+		if !ok {
+			idx = len(keys)
+			index[key] = idx
+			keys = append(keys, key)
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], v)
+	}
+	for i, key := range keys {
+		res.appendRaw(key, agg(groups[i]))
+	}
+	return res
+}
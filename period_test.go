@@ -0,0 +1,124 @@
+package timeseriesgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGroupByPeriodFollowsLocalMidnightAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// Hourly UTC data across the spring DST change (2024-03-31 has 23 local hours).
+	ts := Empty()
+	start := time.Date(2024, 3, 30, 0, 0, 0, 0, loc).UTC()
+	end := time.Date(2024, 4, 1, 0, 0, 0, 0, loc).UTC()
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		ts.AddPoint(DataPoint{t, 1})
+	}
+	daily := ts.GroupByPeriod(PeriodDay, loc, AggregateCount)
+	expected := []float64{24, 23}
+	if daily.Length() != len(expected) {
+		t.Fatalf("expected %d days, got %d", len(expected), daily.Length())
+	}
+	for i, v := range daily.Values() {
+		if v != expected[i] {
+			t.Errorf("day %d expected %v hours, got %v", i, expected[i], v)
+		}
+	}
+	if first := daily.Timestamps()[0]; first.Location() != loc || first.Hour() != 0 {
+		t.Errorf("expected buckets at local midnight, got %v", first)
+	}
+}
+
+func TestGroupByPeriodKeepsRepeatedHourApart(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	// 02:00-03:00 local happens twice on 2024-10-27.
+	ts := Empty()
+	first := time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC) // 02:30 CEST
+	ts.AddPoint(DataPoint{first, 1})
+	ts.AddPoint(DataPoint{first.Add(time.Hour), 2}) // 02:30 CET
+	hourly := ts.GroupByPeriod(PeriodHour, loc, AggregateSum)
+	if hourly.Length() != 2 {
+		t.Errorf("expected the repeated hour to form two groups, got %d", hourly.Length())
+	}
+}
+
+func TestGroupByPeriodCalendarBuckets(t *testing.T) {
+	ts := Empty()
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1}) // Monday
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), 2}) // Sunday
+	ts.AddPoint(DataPoint{time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC), 3})
+	ts.AddPoint(DataPoint{time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), 4})
+
+	cases := []struct {
+		period Period
+		want   []float64
+	}{
+		{PeriodISOWeek, []float64{3, 3, 4}},
+		{PeriodMonth, []float64{3, 3, 4}},
+		{PeriodQuarter, []float64{6, 4}},
+		{PeriodYear, []float64{10}},
+	}
+	for _, c := range cases {
+		got := ts.GroupByPeriod(c.period, time.UTC, AggregateSum)
+		if got.Length() != len(c.want) {
+			t.Fatalf("%s: expected %d groups, got %d", c.period, len(c.want), got.Length())
+		}
+		for i, v := range got.Values() {
+			if v != c.want[i] {
+				t.Errorf("%s idx %d expected %v, got %v", c.period, i, c.want[i], v)
+			}
+		}
+	}
+	quarters := ts.GroupByPeriod(PeriodQuarter, nil, AggregateMedian)
+	if q := quarters.Timestamps()[1]; !q.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected second quarter to start on April 1st, got %v", q)
+	}
+}
+
+func TestGroupByTimeGroupsEveryPointOnce(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	for i := 0; i < 150; i++ {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Minute), float64(i)})
+	}
+	keys := 0
+	grouped := ts.GroupByTime(func(t time.Time) time.Time { keys++; return t.Truncate(time.Hour) }, sum)
+	if keys != ts.Length() {
+		t.Errorf("expected one key call per point, got %d", keys)
+	}
+	// Hours of 60, 60 and 30 minutes: 0+...+59, 60+...+119 and 120+...+149.
+	want := []float64{1770, 5370, 4035}
+	if grouped.Length() != len(want) {
+		t.Fatalf("expected %d groups, got %d", len(want), grouped.Length())
+	}
+	for i, dp := range grouped.DataPoints() {
+		if !dp.Timestamp.Equal(base.Add(time.Duration(i)*time.Hour)) || dp.Value != want[i] {
+			t.Errorf("group %d: expected %v at %v, got %v", i, want[i], base.Add(time.Duration(i)*time.Hour), dp)
+		}
+	}
+}
+
+// BenchmarkGroupByTime groups minute data one group per point; the time per point should
+// stay flat as the size grows.
+func BenchmarkGroupByTime(b *testing.B) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, n := range []int{1000, 10000, 100000} {
+		ts := Empty()
+		for i := 0; i < n; i++ {
+			ts.AddPoint(DataPoint{base.Add(time.Duration(i) * time.Minute), 1})
+		}
+		key := func(t time.Time) time.Time { return t.Truncate(time.Minute) }
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				ts.GroupByTime(key, sum)
+			}
+		})
+	}
+}
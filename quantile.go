@@ -0,0 +1,152 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// QuantileMethod selects one of the nine sample quantile estimators of Hyndman and Fan (1996),
+// numbered as in R's quantile(type = ...).
+type QuantileMethod int
+
+const (
+	// QuantileType1 is the inverse of the empirical CDF.
+	QuantileType1 QuantileMethod = iota + 1
+	// QuantileType2 is like Type1 but averages at discontinuities.
+	QuantileType2
+	// QuantileType3 is the nearest even order statistic (SAS).
+	QuantileType3
+	// QuantileType4 interpolates the empirical CDF linearly.
+	QuantileType4
+	// QuantileType5 puts the k-th value at (k - 0.5)/n (Hazen).
+	QuantileType5
+	// QuantileType6 puts the k-th value at k/(n+1) (Weibull); Percentile uses it.
+	QuantileType6
+	// QuantileType7 puts the k-th value at (k-1)/(n-1); the default of R and numpy.
+	QuantileType7
+	// QuantileType8 is approximately median-unbiased whatever the distribution.
+	QuantileType8
+	// QuantileType9 is approximately unbiased for normally distributed data.
+	QuantileType9
+)
+
+// fuzz absorbs rounding in n*q so that exact ranks are recognised, as R does.
+const fuzz = 4 * 2.220446049250313e-16
+
+/**
+ * Returns the q-quantile (0 <= q <= 1) of values sorted in ascending order without NaN.
+ * Returns NaN for an empty slice, a q outside [0, 1] or an unknown method.
+ */
+func QuantileOfSorted(sorted []float64, q float64, method QuantileMethod) float64 {
+	n := len(sorted)
+	if n == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	// at returns the k-th order statistic, 1-based, clamped to the sample.
+	at := func(k int) float64 {
+		return sorted[min(max(k, 1), n)-1]
+	}
+	nq := float64(n) * q
+	switch method {
+	case QuantileType1, QuantileType2:
+		j := math.Floor(nq + fuzz)
+		if nq-j > fuzz {
+			return at(int(j) + 1)
+		}
+		if method == QuantileType1 {
+			return at(int(j))
+		}
+		return (at(int(j)) + at(int(j)+1)) / 2
+	case QuantileType3:
+		j := math.Floor(nq - 0.5 + fuzz)
+		if nq-0.5-j > fuzz || int(j)%2 != 0 {
+			return at(int(j) + 1)
+		}
+		return at(int(j))
+	}
+
+	var m float64
+	switch method {
+	case QuantileType4:
+		m = 0
+	case QuantileType5:
+		m = 0.5
+	case QuantileType6:
+		m = q
+	case QuantileType7:
+		m = 1 - q
+	case QuantileType8:
+		m = (q + 1) / 3
+	case QuantileType9:
+		m = q/4 + 3.0/8
+	default:
+		return math.NaN()
+	}
+	h := nq + m
+	j := math.Floor(h + fuzz)
+	if h < 1 {
+		return sorted[0]
+	}
+	if j >= float64(n) {
+		return sorted[n-1]
+	}
+	lower := at(int(j))
+	if g := h - j; g > fuzz {
+		return lower + g*(at(int(j)+1)-lower)
+	}
+	return lower
+}
+
+// sortedValues returns the non-NaN values in ascending order, in a new slice.
+func sortedValues(values []float64) []float64 {
+	sorted := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+	return sorted
+}
+
+/**
+ * Estimates the q-quantile of the values, ignoring NaN.
+ *
+ * @param q The quantile, between 0 and 1.
+ * @param method The estimator; QuantileType7 matches R and numpy defaults.
+ *
+ * @return The estimate, or an error if there are no values or q or method are invalid.
+ */
+func (ts *TimeSeries) Quantile(q float64, method QuantileMethod) (float64, error) {
+	res, err := ts.Quantiles([]float64{q}, method)
+	if err != nil {
+		return 0.0, err
+	}
+	return res[0], nil
+}
+
+/**
+ * Estimates several quantiles at once, sorting the values a single time.
+ *
+ * @return One estimate per element of qs, or an error if there are no values or any q or method is invalid.
+ */
+func (ts *TimeSeries) Quantiles(qs []float64, method QuantileMethod) ([]float64, error) {
+	if method < QuantileType1 || method > QuantileType9 {
+		return nil, errors.New("unknown quantile method")
+	}
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			return nil, errors.New("quantile must be between 0 and 1")
+		}
+	}
+	sorted := sortedValues(ts.values)
+	if len(sorted) == 0 {
+		return nil, errors.New("timeseries is empty")
+	}
+	res := make([]float64, len(qs))
+	for i, q := range qs {
+		res[i] = QuantileOfSorted(sorted, q, method)
+	}
+	return res, nil
+}
@@ -0,0 +1,71 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestQuantileMethods(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, []float64{3, 1, 4, 1, math.NaN(), 5, 9, 2, 6})
+	// Values from R: quantile(c(3, 1, 4, 1, 5, 9, 2, 6), 0.25, type = k).
+	want := []float64{1, 1.5, 1, 1, 1.5, 1.25, 1.75, 1 + 1.25/3, 1.4375}
+	for k, w := range want {
+		method := QuantileMethod(k + 1)
+		got, err := ts.Quantile(0.25, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-w) > 1e-12 {
+			t.Errorf("type %d: expected %v, got %v", method, w, got)
+		}
+	}
+	if got, _ := ts.Quantile(0.5, QuantileType3); got != 3 {
+		t.Errorf("type 3 median: expected 3, got %v", got)
+	}
+}
+
+func TestQuantiles(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{50, 10, 40, 20, 30})
+	got, err := ts.Quantiles([]float64{0, 0.5, 1}, QuantileType7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range []float64{10, 30, 50} {
+		if got[i] != w {
+			t.Errorf("idx %d: expected %v, got %v", i, w, got[i])
+		}
+	}
+	if _, err := ts.Quantiles([]float64{0.5, 1.5}, QuantileType7); err == nil {
+		t.Error("expected an error for q > 1")
+	}
+	if _, err := ts.Quantile(0.5, QuantileMethod(10)); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+	empty := Empty()
+	if _, err := empty.Quantile(0.5, QuantileType7); err == nil {
+		t.Error("expected an error for an empty series")
+	}
+}
+
+func TestMedianUnsorted(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{9, 1, 7, 3, 5})
+	if m, _ := ts.Median(); m != 5 {
+		t.Errorf("expected median 5, got %v", m)
+	}
+	if p, _ := ts.Percentile(25); p != 2 {
+		t.Errorf("expected 25th percentile 2, got %v", p)
+	}
+}
+
+func TestQuantileOfSorted(t *testing.T) {
+	if !math.IsNaN(QuantileOfSorted(nil, 0.5, QuantileType7)) {
+		t.Error("expected NaN for no values")
+	}
+	if got := QuantileOfSorted([]float64{1, 2, 3, 4}, 1, QuantileType1); got != 4 {
+		t.Errorf("expected 4, got %v", got)
+	}
+}
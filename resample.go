@@ -0,0 +1,243 @@
+package timeseriesgo
+
+import (
+	"math"
+	"time"
+)
+
+// FillMethod selects how Upsample and FillGaps fill grid ticks that have no point of their own.
+type FillMethod int
+
+const (
+	// FillForward repeats the last value before the tick.
+	FillForward FillMethod = iota
+	// FillBackward uses the first value after the tick.
+	FillBackward
+	// FillNearest uses the closer of the two neighbours; on a tie the earlier one wins.
+	FillNearest
+	// FillLinear interpolates linearly in time between the two neighbours.
+	FillLinear
+	// FillConstant uses ResampleOptions.FillValue.
+	FillConstant
+	// FillSeasonal repeats the value one season earlier (see ResampleOptions.Season and
+	// GapFillOptions.Season); a tick with nothing one season earlier is left out.
+	FillSeasonal
+)
+
+// BinSide names one end of a resampling bucket.
+type BinSide int
+
+const (
+	// SideLeft is the start of the bucket.
+	SideLeft BinSide = iota
+	// SideRight is the end of the bucket.
+	SideRight
+)
+
+// ResampleOptions tunes the bucket grid. The zero value gives left-closed buckets labelled by
+// their start, with fixed-duration grids anchored at midnight of the first point's day.
+type ResampleOptions struct {
+	// Origin anchors fixed-duration grids (Every); calendar frequencies use their own anchors.
+	Origin time.Time
+	// Offset shifts every bucket edge, e.g. 6h turns daily buckets into 06:00-06:00 days.
+	Offset time.Duration
+	// Label selects which edge stamps a downsampled bucket.
+	Label BinSide
+	// Closed selects which edge belongs to a downsampled bucket.
+	Closed BinSide
+	// FillValue is used by FillConstant.
+	FillValue float64
+	// Season is the number of ticks in one season for FillSeasonal in Upsample.
+	Season int
+}
+
+// binner maps timestamps to buckets on a resolved grid.
+type binner struct {
+	freq        Frequency
+	offset      time.Duration
+	closedRight bool
+	degenerate  bool
+}
+
+// newBinner resolves freq and opts against the first timestamp of the data.
+func newBinner(freq Frequency, opts ResampleOptions, first time.Time) binner {
+	if f, ok := freq.(fixedFrequency); ok {
+		origin := opts.Origin
+		if origin.IsZero() {
+			y, m, d := first.Date()
+			origin = time.Date(y, m, d, 0, 0, 0, 0, first.Location())
+		}
+		freq = EveryFrom(time.Duration(f), origin)
+	}
+	return binner{freq: freq, offset: opts.Offset, closedRight: opts.Closed == SideRight, degenerate: !advances(freq, first)}
+}
+
+// bounds returns the edges of the bucket holding t.
+func (b binner) bounds(t time.Time) (time.Time, time.Time) {
+	if b.degenerate {
+		return t, t
+	}
+	u := t.Add(-b.offset)
+	start := b.freq.Floor(u)
+	if b.closedRight && start.Equal(u) {
+		start = b.freq.Shift(start, -1)
+	}
+	return start.Add(b.offset), b.freq.Shift(start, 1).Add(b.offset)
+}
+
+// contains reports whether t falls in the bucket with the given edges.
+func (b binner) contains(t, start, end time.Time) bool {
+	if b.degenerate {
+		return t.Equal(start)
+	}
+	if b.closedRight {
+		return t.After(start) && !t.After(end)
+	}
+	return !t.Before(start) && t.Before(end)
+}
+
+// tick returns the first grid timestamp at or after t.
+func (b binner) tick(t time.Time) time.Time {
+	return Ceil(b.freq, t.Add(-b.offset)).Add(b.offset)
+}
+
+// buckets calls emit once per non-empty bucket with its label and values, in a single pass over sorted data.
+func (ts *TimeSeries) buckets(freq Frequency, opts ResampleOptions, emit func(label time.Time, values []float64)) {
+	if ts.IsEmpty() {
+		return
+	}
+	core := ts.core()
+	b := newBinner(freq, opts, core.timeAt(0))
+	lo := 0
+	for lo < len(ts.times) {
+		start, end := b.bounds(core.timeAt(lo))
+		hi := lo + 1
+		for hi < len(ts.times) && b.contains(core.timeAt(hi), start, end) {
+			hi++
+		}
+		label := start
+		if opts.Label == SideRight && !b.degenerate {
+			label = end
+		}
+		emit(label, ts.values[lo:hi:hi])
+		lo = hi
+	}
+}
+
+/**
+ * Downsamples a sorted TimeSeries: every bucket of freq is reduced to one value.
+ * Buckets without points are left out. The series is read once.
+ *
+ * @param freq The bucket size, e.g. Every(time.Hour) or MonthStart(1).
+ * @param agg Reduces the values of a bucket, e.g. AggregateMean.
+ * @param opts Origin, offset, label side and closed side of the buckets.
+ *
+ * @return A TimeSeries with one point per non-empty bucket. If freq does not advance, every timestamp is its own bucket.
+ */
+func (ts *TimeSeries) Downsample(freq Frequency, agg Aggregator, opts ResampleOptions) TimeSeries {
+	res := ts.derive()
+	ts.buckets(freq, opts, func(label time.Time, values []float64) {
+		res.appendRaw(label.UnixNano(), agg(values))
+	})
+	return res
+}
+
+/**
+ * Computes open, high, low and close values per bucket of freq.
+ *
+ * @return A MultiSeries with the columns "open", "high", "low" and "close", labelled like the input;
+ *         every column keeps the tags and unit of the input.
+ */
+func (ts *TimeSeries) OHLC(freq Frequency, opts ResampleOptions) MultiSeries {
+	res := MultiSeries{
+		loc:     ts.loc,
+		columns: []string{"open", "high", "low", "close"},
+		values:  make([][]float64, 4),
+		label:   ts.label,
+		meta:    make([]Metadata, 4),
+	}
+	for k, column := range res.columns {
+		res.meta[k] = ts.Metadata()
+		res.meta[k].Name = column
+	}
+	aggs := []Aggregator{AggregateFirst, AggregateMax, AggregateMin, AggregateLast}
+	ts.buckets(freq, opts, func(label time.Time, values []float64) {
+		res.timestamps = append(res.timestamps, label.UnixNano())
+		for k, agg := range aggs {
+			res.values[k] = append(res.values[k], agg(values))
+		}
+	})
+	return res
+}
+
+/**
+ * Upsamples a sorted TimeSeries onto the ticks of freq between its first and last point.
+ * A tick that holds a point keeps its value (the last one for duplicate timestamps);
+ * the others are filled with method. FillSeasonal copies the tick Season ticks earlier and
+ * leaves a tick out when there is none, as FillGaps does; an unknown method fills NaN.
+ * Only Origin, Offset, FillValue and Season of opts apply.
+ *
+ * @param freq The target grid, finer than the data.
+ * @param method How to fill ticks without a point.
+ * @param opts Grid anchoring and the constant fill value.
+ *
+ * @return A TimeSeries on the grid. If freq does not advance, returns a copy.
+ */
+func (ts *TimeSeries) Upsample(freq Frequency, method FillMethod, opts ResampleOptions) TimeSeries {
+	if ts.IsEmpty() {
+		return ts.derive()
+	}
+	core := ts.core()
+	b := newBinner(freq, opts, core.timeAt(0))
+	if b.degenerate {
+		return ts.withPoints(ts.DataPoints())
+	}
+	res := ts.derive()
+	last := ts.times[len(ts.times)-1]
+	j := 0
+	for t := b.tick(core.timeAt(0)); t.UnixNano() <= last; t = b.freq.Shift(t, 1) {
+		n := t.UnixNano()
+		for j < len(ts.times) && ts.times[j] < n {
+			j++
+		}
+		if ts.times[j] == n {
+			for j+1 < len(ts.times) && ts.times[j+1] == n {
+				j++
+			}
+			res.appendRaw(n, ts.values[j])
+			continue
+		}
+		if method == FillSeasonal {
+			if opts.Season > 0 {
+				if k := res.IndexOf(b.freq.Shift(t, -opts.Season)); k >= 0 {
+					res.appendRaw(n, res.values[k])
+				}
+			}
+			continue
+		}
+		// The first tick is at or after the first point, so there is always a point on each side.
+		res.appendRaw(n, fillBetween(method, ts.times[j-1], ts.values[j-1], ts.times[j], ts.values[j], n, opts.FillValue))
+	}
+	return res
+}
+
+// fillBetween computes the value at t strictly between points (t0, v0) and (t1, v1).
+// FillSeasonal needs more than the neighbours, so callers handle it; it and unknown methods give NaN.
+func fillBetween(method FillMethod, t0 int64, v0 float64, t1 int64, v1 float64, t int64, fill float64) float64 {
+	switch method {
+	case FillForward:
+		return v0
+	case FillBackward:
+		return v1
+	case FillNearest:
+		if t-t0 <= t1-t {
+			return v0
+		}
+		return v1
+	case FillLinear:
+		return v0 + (v1-v0)*float64(t-t0)/float64(t1-t0)
+	case FillConstant:
+		return fill
+	}
+	return math.NaN()
+}
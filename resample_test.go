@@ -0,0 +1,178 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func minuteSeries(base time.Time, offsets []int, values []float64) TimeSeries {
+	ts := EmptyLabeled("load")
+	for i, m := range offsets {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(m) * time.Minute), values[i]})
+	}
+	return ts
+}
+
+func TestDownsampleAggregators(t *testing.T) {
+	base := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{5, 20, 50, 65, 130}, []float64{1, 3, 2, 10, 4})
+
+	cases := []struct {
+		agg  Aggregator
+		want []float64
+	}{
+		{AggregateMean, []float64{2, 10, 4}},
+		{AggregateSum, []float64{6, 10, 4}},
+		{AggregateMin, []float64{1, 10, 4}},
+		{AggregateMax, []float64{3, 10, 4}},
+		{AggregateFirst, []float64{1, 10, 4}},
+		{AggregateLast, []float64{2, 10, 4}},
+		{AggregateCount, []float64{3, 1, 1}},
+	}
+	for _, c := range cases {
+		got := ts.Downsample(Every(time.Hour), c.agg, ResampleOptions{})
+		if got.Length() != len(c.want) {
+			t.Fatalf("expected %d buckets, got %d", len(c.want), got.Length())
+		}
+		for i, v := range got.Values() {
+			if v != c.want[i] {
+				t.Errorf("idx %d expected %v, got %v", i, c.want[i], v)
+			}
+		}
+	}
+	got := ts.Downsample(Every(time.Hour), AggregateSum, ResampleOptions{})
+	times := got.Timestamps()
+	if !times[0].Equal(base) || !times[2].Equal(base.Add(2*time.Hour)) {
+		t.Errorf("expected buckets labelled by their hour, got %v", times)
+	}
+	if got.Label() != "load" {
+		t.Errorf("expected label to be kept, got %q", got.Label())
+	}
+}
+
+func TestDownsampleLabelClosedAndOffset(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 30, 60, 90}, []float64{1, 2, 3, 4})
+
+	right := ts.Downsample(Every(time.Hour), AggregateSum, ResampleOptions{Closed: SideRight, Label: SideRight})
+	// (23:00, 00:00] holds 1, (00:00, 01:00] holds 2+3, (01:00, 02:00] holds 4.
+	expected := []float64{1, 5, 4}
+	expectedTimes := []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}
+	if right.Length() != len(expected) {
+		t.Fatalf("expected %d buckets, got %d", len(expected), right.Length())
+	}
+	for i, dp := range right.DataPoints() {
+		if dp.Value != expected[i] || !dp.Timestamp.Equal(expectedTimes[i]) {
+			t.Errorf("idx %d expected %v at %v, got %v at %v", i, expected[i], expectedTimes[i], dp.Value, dp.Timestamp)
+		}
+	}
+
+	shifted := ts.Downsample(Every(time.Hour), AggregateSum, ResampleOptions{Offset: 30 * time.Minute})
+	expected = []float64{1, 5, 4}
+	for i, dp := range shifted.DataPoints() {
+		if dp.Value != expected[i] {
+			t.Errorf("idx %d expected %v, got %v", i, expected[i], dp.Value)
+		}
+	}
+	if first := shifted.Timestamps()[0]; !first.Equal(base.Add(-30 * time.Minute)) {
+		t.Errorf("expected first bucket at 23:30, got %v", first)
+	}
+
+	origin := ts.Downsample(Every(time.Hour), AggregateCount, ResampleOptions{Origin: base.Add(15 * time.Minute)})
+	if first := origin.Timestamps()[0]; !first.Equal(base.Add(-45 * time.Minute)) {
+		t.Errorf("expected first bucket anchored at origin, got %v", first)
+	}
+}
+
+func TestDownsampleCalendarAndOHLC(t *testing.T) {
+	ts := Empty()
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), 5})
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), 9})
+	ts.AddPoint(DataPoint{time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC), 1})
+	ts.AddPoint(DataPoint{time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC), 7})
+
+	monthly := ts.Downsample(MonthStart(1), AggregateMean, ResampleOptions{})
+	if monthly.Length() != 2 || monthly.Values()[0] != 5 || !monthly.Timestamps()[1].Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected monthly means %v at %v", monthly.Values(), monthly.Timestamps())
+	}
+
+	ohlc := ts.OHLC(MonthStart(1), ResampleOptions{})
+	_, row, err := ohlc.Row(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{5, 9, 1, 1}
+	for k, v := range row {
+		if v != expected[k] {
+			t.Errorf("column %s expected %v, got %v", ohlc.Columns()[k], expected[k], v)
+		}
+	}
+	if ohlc.Length() != 2 {
+		t.Errorf("expected 2 OHLC rows, got %d", ohlc.Length())
+	}
+}
+
+func TestUpsampleFillMethods(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 40}, []float64{0, 8})
+
+	cases := []struct {
+		method FillMethod
+		want   []float64
+	}{
+		{FillForward, []float64{0, 0, 0, 0, 0, 8}},
+		{FillBackward, []float64{0, 8, 8, 8, 8, 8}},
+		{FillNearest, []float64{0, 0, 0, 8, 8, 8}},
+		{FillLinear, []float64{0, 1.6, 3.2, 4.8, 6.4, 8}},
+		{FillConstant, []float64{0, -1, -1, -1, -1, 8}},
+	}
+	for _, c := range cases {
+		// An 8 minute grid from midnight: the point at 40 minutes is on tick 5.
+		got := ts.Upsample(Every(8*time.Minute), c.method, ResampleOptions{FillValue: -1})
+		if got.Length() != len(c.want) {
+			t.Fatalf("method %d: expected %d ticks, got %d", c.method, len(c.want), got.Length())
+		}
+		for i, v := range got.Values() {
+			if math.Abs(v-c.want[i]) > 1e-9 {
+				t.Errorf("method %d idx %d expected %v, got %v", c.method, i, c.want[i], v)
+			}
+		}
+	}
+
+	if got := ts.Upsample(Every(0), FillForward, ResampleOptions{}); got.Length() != ts.Length() {
+		t.Errorf("expected a copy for a zero frequency, got %d points", got.Length())
+	}
+}
+
+func TestUpsampleSeasonalAndUnknownMethods(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 6}, []float64{1, 2, 3, 4, 7})
+	minute := Every(time.Minute)
+
+	seasonal := ts.Upsample(minute, FillSeasonal, ResampleOptions{Season: 2})
+	assertSeriesValues(t, "seasonal", seasonal, []float64{1, 2, 3, 4, 3, 4, 7})
+
+	// Without a season there is nothing to copy: the missing ticks are left out, not forward filled.
+	noSeason := ts.Upsample(minute, FillSeasonal, ResampleOptions{})
+	assertSeriesValues(t, "no season", noSeason, []float64{1, 2, 3, 4, 7})
+
+	nan := math.NaN()
+	unknown := ts.Upsample(minute, FillMethod(42), ResampleOptions{})
+	assertSeriesValues(t, "unknown", unknown, []float64{1, 2, 3, 4, nan, nan, 7})
+}
+
+func TestResampleIsLinearOnLargeInput(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	for i := 0; i < 200000; i++ {
+		ts.AddPoint(DataPoint{base.Add(time.Duration(2*i) * time.Second), float64(i)})
+	}
+	lin := ts.Interpolate(time.Second)
+	if lin.Length() != 2*200000-1 {
+		t.Fatalf("expected %d points, got %d", 2*200000-1, lin.Length())
+	}
+	if v := lin.Values()[1]; v != 0.5 {
+		t.Errorf("expected 0.5, got %v", v)
+	}
+}
@@ -0,0 +1,317 @@
+package timeseriesgo
+
+import (
+	"math"
+	"time"
+)
+
+type windowKind int
+
+const (
+	timeWindow windowKind = iota
+	countWindow
+)
+
+// Window describes which points surround each output point of a rolling computation.
+// Build one with TimeWindow or CountWindow and refine it with Centered and WithMinPeriods.
+type Window struct {
+	kind       windowKind
+	period     Frequency
+	size       int
+	centered   bool
+	minPeriods int
+}
+
+/**
+ * Returns a trailing time window (t - d, t]. Points with the same timestamp as t are always included.
+ */
+func TimeWindow(d time.Duration) Window {
+	return TimeWindowWithFrequency(Every(d))
+}
+
+/**
+ * Returns a trailing time window of one period of freq, (freq.Shift(t, -1), t],
+ * e.g. MonthStart(1) for the trailing calendar month.
+ */
+func TimeWindowWithFrequency(freq Frequency) Window {
+	return Window{kind: timeWindow, period: freq}
+}
+
+/**
+ * Returns a window of the last n points, including the current one. n below 1 is treated as 1.
+ */
+func CountWindow(n int) Window {
+	return Window{kind: countWindow, size: max(n, 1)}
+}
+
+/**
+ * Returns the window centred on each point instead of ending at it. A centred count window of
+ * n points reaches (n-1)/2 points back for odd n and one point further back for even n, like pandas.
+ * A centred time window has the length of the period ending at t, split evenly around t.
+ */
+func (w Window) Centered() Window {
+	w.centered = true
+	return w
+}
+
+/**
+ * Returns the window with a minimum number of non-NaN values; outputs with fewer are NaN.
+ * The default is 1.
+ */
+func (w Window) WithMinPeriods(n int) Window {
+	w.minPeriods = n
+	return w
+}
+
+// bounds returns, for every point, the half-open index range [lo, hi) of its window.
+// Both ends only move forward, which is what lets the aggregations run incrementally.
+func (w Window) bounds(times []int64, loc *time.Location) (los, his []int) {
+	n := len(times)
+	los, his = make([]int, n), make([]int, n)
+	if w.kind == countWindow {
+		offset := 0
+		if w.centered {
+			offset = (w.size - 1) / 2
+		}
+		for i := range times {
+			his[i] = min(i+offset+1, n)
+			los[i] = max(i+offset+1-w.size, 0)
+		}
+		return los, his
+	}
+
+	lo, hi := 0, 0
+	for i, t := range times {
+		at := time.Unix(0, t).In(loc)
+		from := w.period.Shift(at, -1).UnixNano()
+		to := t
+		if w.centered {
+			half := (t - from) / 2
+			from, to = t-half, t+(t-from)-half
+		}
+		// A window always holds the points stamped t, even for a period that does not advance.
+		from = min(from, t-1)
+		to = max(to, t)
+		for lo < n && times[lo] <= from {
+			lo++
+		}
+		for hi < n && times[hi] <= to {
+			hi++
+		}
+		los[i], his[i] = lo, hi
+	}
+	return los, his
+}
+
+// accumulator is an incremental aggregation over a sliding index range.
+// add and remove are only called for non-NaN values, in index order.
+type accumulator interface {
+	add(i int, v float64)
+	remove(i int, v float64)
+	value() float64
+}
+
+// Rolling computes statistics over a Window sliding along a series.
+type Rolling struct {
+	ts     *TimeSeries
+	window Window
+}
+
+/**
+ * Returns the rolling view of the series for w. The series must be sorted.
+ * Every statistic is computed in one pass: sum, mean, variance and count in O(1) per point,
+ * min and max with a monotonic deque. NaN values are skipped.
+ */
+func (ts *TimeSeries) Rolling(w Window) Rolling {
+	return Rolling{ts: ts, window: w}
+}
+
+// run slides acc along the series and emits its value for every point with enough values.
+func (r Rolling) run(acc accumulator) TimeSeries {
+	ts := r.ts
+	res := ts.derive()
+	los, his := r.window.bounds(ts.times, ts.core().Location())
+	minPeriods := max(r.window.minPeriods, 1)
+	lo, hi, valid := 0, 0, 0
+	for i := range ts.times {
+		for ; hi < his[i]; hi++ {
+			if v := ts.values[hi]; !math.IsNaN(v) {
+				acc.add(hi, v)
+				valid++
+			}
+		}
+		for ; lo < los[i]; lo++ {
+			if v := ts.values[lo]; !math.IsNaN(v) {
+				acc.remove(lo, v)
+				valid--
+			}
+		}
+		out := math.NaN()
+		if valid >= minPeriods {
+			out = acc.value()
+		}
+		res.appendRaw(ts.times[i], out)
+	}
+	return res
+}
+
+// Sum returns the rolling sum.
+func (r Rolling) Sum() TimeSeries {
+	return r.run(&sumAcc{})
+}
+
+// Mean returns the rolling mean.
+func (r Rolling) Mean() TimeSeries {
+	return r.run(&meanAcc{})
+}
+
+// Var returns the rolling sample variance; NaN where the window has fewer than two values.
+func (r Rolling) Var() TimeSeries {
+	return r.run(&varAcc{})
+}
+
+// Std returns the rolling sample standard deviation.
+func (r Rolling) Std() TimeSeries {
+	return r.run(&varAcc{std: true})
+}
+
+// Count returns the number of non-NaN values in each window.
+func (r Rolling) Count() TimeSeries {
+	return r.run(&countAcc{})
+}
+
+// Min returns the rolling minimum.
+func (r Rolling) Min() TimeSeries {
+	return r.run(&extremeAcc{less: func(a, b float64) bool { return a < b }})
+}
+
+// Max returns the rolling maximum.
+func (r Rolling) Max() TimeSeries {
+	return r.run(&extremeAcc{less: func(a, b float64) bool { return a > b }})
+}
+
+/**
+ * Applies f to the values of every window (NaN values included) where the window holds
+ * at least the minimum number of non-NaN values. f gets a copy of the window, so it may
+ * modify it, but the slice is reused between calls and must not be kept.
+ * This costs O(window) per point on top of f.
+ */
+func (r Rolling) Apply(f func([]float64) float64) TimeSeries {
+	ts := r.ts
+	res := ts.derive()
+	los, his := r.window.bounds(ts.times, ts.core().Location())
+	minPeriods := max(r.window.minPeriods, 1)
+	// valid[k] is the number of non-NaN values before index k.
+	valid := make([]int, len(ts.values)+1)
+	for k, v := range ts.values {
+		valid[k+1] = valid[k]
+		if !math.IsNaN(v) {
+			valid[k+1]++
+		}
+	}
+	var buf []float64
+	for i := range ts.times {
+		out := math.NaN()
+		if valid[his[i]]-valid[los[i]] >= minPeriods {
+			buf = append(buf[:0], ts.values[los[i]:his[i]]...)
+			out = f(buf)
+		}
+		res.appendRaw(ts.times[i], out)
+	}
+	return res
+}
+
+type sumAcc struct {
+	sum float64
+}
+
+func (a *sumAcc) add(_ int, v float64)    { a.sum += v }
+func (a *sumAcc) remove(_ int, v float64) { a.sum -= v }
+func (a *sumAcc) value() float64          { return a.sum }
+
+type countAcc struct {
+	n int
+}
+
+func (a *countAcc) add(int, float64)    { a.n++ }
+func (a *countAcc) remove(int, float64) { a.n-- }
+func (a *countAcc) value() float64      { return float64(a.n) }
+
+type meanAcc struct {
+	sum float64
+	n   int
+}
+
+func (a *meanAcc) add(_ int, v float64)    { a.sum += v; a.n++ }
+func (a *meanAcc) remove(_ int, v float64) { a.sum -= v; a.n-- }
+func (a *meanAcc) value() float64          { return a.sum / float64(a.n) }
+
+// varAcc keeps Welford's running mean and sum of squared deviations.
+type varAcc struct {
+	n    int
+	mean float64
+	m2   float64
+	std  bool
+}
+
+func (a *varAcc) add(_ int, v float64) {
+	a.n++
+	d := v - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (v - a.mean)
+}
+
+func (a *varAcc) remove(_ int, v float64) {
+	if a.n == 1 {
+		a.n, a.mean, a.m2 = 0, 0, 0
+		return
+	}
+	a.n--
+	d := v - a.mean
+	a.mean -= d / float64(a.n)
+	a.m2 -= d * (v - a.mean)
+}
+
+func (a *varAcc) value() float64 {
+	if a.n < 2 {
+		return math.NaN()
+	}
+	v := math.Max(a.m2, 0) / float64(a.n-1)
+	if a.std {
+		return math.Sqrt(v)
+	}
+	return v
+}
+
+// extremeAcc is a monotonic deque of indices: values from front to back are ordered by less,
+// so the front is the extreme of the window.
+type extremeAcc struct {
+	idx  []int
+	vals []float64
+	head int
+	less func(a, b float64) bool
+}
+
+func (a *extremeAcc) add(i int, v float64) {
+	for len(a.vals) > a.head && !a.less(a.vals[len(a.vals)-1], v) {
+		a.idx, a.vals = a.idx[:len(a.idx)-1], a.vals[:len(a.vals)-1]
+	}
+	a.idx = append(a.idx, i)
+	a.vals = append(a.vals, v)
+}
+
+func (a *extremeAcc) remove(i int, _ float64) {
+	if a.head < len(a.idx) && a.idx[a.head] == i {
+		a.head++
+	}
+	// Compact once the consumed prefix dominates, keeping memory proportional to the window.
+	if a.head > 64 && a.head*2 > len(a.idx) {
+		a.idx = append(a.idx[:0], a.idx[a.head:]...)
+		a.vals = append(a.vals[:0], a.vals[a.head:]...)
+		a.head = 0
+	}
+}
+
+func (a *extremeAcc) value() float64 {
+	return a.vals[a.head]
+}
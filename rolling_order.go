@@ -0,0 +1,249 @@
+package timeseriesgo
+
+import "math"
+
+// orderStats is a multiset of the values in one window supporting insert, delete, k-th
+// smallest and rank in O(log w) expected: a treap ordered by value whose nodes count their
+// subtree. Nodes live in one slice and are recycled, so memory follows the window size.
+type orderStats struct {
+	nodes []orderNode // nodes[0] is the empty tree
+	free  []int
+	root  int
+	seed  uint64
+}
+
+type orderNode struct {
+	value       float64
+	priority    uint64
+	left, right int
+	size        int
+}
+
+func newOrderStats() *orderStats {
+	return &orderStats{nodes: make([]orderNode, 1), seed: 0x9e3779b97f4a7c15}
+}
+
+func (o *orderStats) size() int {
+	return o.nodes[o.root].size
+}
+
+func (o *orderStats) update(v float64, delta int) {
+	if delta > 0 {
+		o.root = o.insert(o.root, v)
+	} else {
+		o.root = o.remove(o.root, v)
+	}
+}
+
+func (o *orderStats) newNode(v float64) int {
+	// xorshift64: priorities only need to look random, not be unpredictable.
+	o.seed ^= o.seed << 13
+	o.seed ^= o.seed >> 7
+	o.seed ^= o.seed << 17
+	node := orderNode{value: v, priority: o.seed, size: 1}
+	if n := len(o.free); n > 0 {
+		i := o.free[n-1]
+		o.free = o.free[:n-1]
+		o.nodes[i] = node
+		return i
+	}
+	o.nodes = append(o.nodes, node)
+	return len(o.nodes) - 1
+}
+
+func (o *orderStats) pull(t int) {
+	o.nodes[t].size = o.nodes[o.nodes[t].left].size + o.nodes[o.nodes[t].right].size + 1
+}
+
+func (o *orderStats) rotateRight(t int) int {
+	l := o.nodes[t].left
+	o.nodes[t].left = o.nodes[l].right
+	o.nodes[l].right = t
+	o.pull(t)
+	o.pull(l)
+	return l
+}
+
+func (o *orderStats) rotateLeft(t int) int {
+	r := o.nodes[t].right
+	o.nodes[t].right = o.nodes[r].left
+	o.nodes[r].left = t
+	o.pull(t)
+	o.pull(r)
+	return r
+}
+
+func (o *orderStats) insert(t int, v float64) int {
+	if t == 0 {
+		return o.newNode(v)
+	}
+	if v < o.nodes[t].value {
+		l := o.insert(o.nodes[t].left, v)
+		o.nodes[t].left = l
+		o.pull(t)
+		if o.nodes[l].priority > o.nodes[t].priority {
+			t = o.rotateRight(t)
+		}
+	} else {
+		r := o.insert(o.nodes[t].right, v)
+		o.nodes[t].right = r
+		o.pull(t)
+		if o.nodes[r].priority > o.nodes[t].priority {
+			t = o.rotateLeft(t)
+		}
+	}
+	return t
+}
+
+// remove deletes one node holding v, rotating it down until it has at most one child.
+func (o *orderStats) remove(t int, v float64) int {
+	if t == 0 {
+		return 0
+	}
+	n := o.nodes[t]
+	switch {
+	case v < n.value:
+		o.nodes[t].left = o.remove(n.left, v)
+	case v > n.value:
+		o.nodes[t].right = o.remove(n.right, v)
+	case n.left == 0 || n.right == 0:
+		o.free = append(o.free, t)
+		return n.left + n.right
+	case o.nodes[n.left].priority > o.nodes[n.right].priority:
+		t = o.rotateRight(t)
+		o.nodes[t].right = o.remove(o.nodes[t].right, v)
+	default:
+		t = o.rotateLeft(t)
+		o.nodes[t].left = o.remove(o.nodes[t].left, v)
+	}
+	o.pull(t)
+	return t
+}
+
+// kth returns the k-th smallest value, 1-based.
+func (o *orderStats) kth(k int) float64 {
+	t := o.root
+	for {
+		n := o.nodes[t]
+		left := o.nodes[n.left].size
+		switch {
+		case k <= left:
+			t = n.left
+		case k == left+1:
+			return n.value
+		default:
+			k -= left + 1
+			t = n.right
+		}
+	}
+}
+
+// countAtMost returns how many values are <= v.
+func (o *orderStats) countAtMost(v float64) int {
+	count := 0
+	for t := o.root; t != 0; {
+		n := o.nodes[t]
+		if n.value <= v {
+			count += o.nodes[n.left].size + 1
+			t = n.right
+		} else {
+			t = n.left
+		}
+	}
+	return count
+}
+
+// quantile interpolates linearly between the closest ranks at (size-1)*q.
+func (o *orderStats) quantile(q float64) float64 {
+	h := float64(o.size()-1) * q
+	lo := int(math.Floor(h))
+	v := o.kth(lo + 1)
+	if frac := h - float64(lo); frac > 0 {
+		v += frac * (o.kth(lo+2) - v)
+	}
+	return v
+}
+
+// deviation returns the k-th smallest |x - m|, 1-based. The deviations of the values at or
+// below m and above m form two sorted sequences; the k-th of their union is found by binary
+// search on how many come from the lower side, with O(log w) access to each.
+func (o *orderStats) deviation(m float64, k int) float64 {
+	below := o.countAtMost(m)
+	above := o.size() - below
+	lower := func(j int) float64 { return m - o.kth(below-j+1) }
+	upper := func(j int) float64 { return o.kth(below+j) - m }
+
+	lo, hi := max(0, k-above), min(k, below)
+	for lo < hi {
+		i := (lo + hi) / 2
+		if lower(i+1) < upper(k-i) {
+			lo = i + 1
+		} else {
+			hi = i
+		}
+	}
+	res := math.Inf(-1)
+	if lo > 0 {
+		res = lower(lo)
+	}
+	if k-lo > 0 {
+		res = math.Max(res, upper(k-lo))
+	}
+	return res
+}
+
+// mad is the median of |x - median|.
+func (o *orderStats) mad() float64 {
+	m := o.quantile(0.5)
+	size := o.size()
+	half := (size + 1) / 2
+	d := o.deviation(m, half)
+	if size%2 == 0 {
+		d = (d + o.deviation(m, half+1)) / 2
+	}
+	return d
+}
+
+type quantileAcc struct {
+	stats *orderStats
+	q     float64
+}
+
+func (a *quantileAcc) add(_ int, v float64)    { a.stats.update(v, 1) }
+func (a *quantileAcc) remove(_ int, v float64) { a.stats.update(v, -1) }
+func (a *quantileAcc) value() float64          { return a.stats.quantile(a.q) }
+
+type madAcc struct {
+	stats *orderStats
+}
+
+func (a *madAcc) add(_ int, v float64)    { a.stats.update(v, 1) }
+func (a *madAcc) remove(_ int, v float64) { a.stats.update(v, -1) }
+func (a *madAcc) value() float64          { return a.stats.mad() }
+
+/**
+ * Returns the rolling median. Like Quantile, it keeps the window in an order-statistics tree,
+ * so each point costs O(log w) for a window of w points.
+ */
+func (r Rolling) Median() TimeSeries {
+	return r.Quantile(0.5)
+}
+
+/**
+ * Returns the rolling q-quantile (0 <= q <= 1), interpolating linearly between the closest
+ * ranks (QuantileType7, the numpy and pandas default). q outside [0, 1] gives NaN.
+ */
+func (r Rolling) Quantile(q float64) TimeSeries {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return r.ts.MapValues(func(float64) float64 { return math.NaN() })
+	}
+	return r.run(&quantileAcc{stats: newOrderStats(), q: q})
+}
+
+/**
+ * Returns the rolling median absolute deviation, median(|x - median(x)|), without scaling.
+ * Multiply by 1.4826 for a standard deviation estimate under normality. O(log² w) per point.
+ */
+func (r Rolling) MAD() TimeSeries {
+	return r.run(&madAcc{stats: newOrderStats()})
+}
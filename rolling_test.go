@@ -0,0 +1,191 @@
+package timeseriesgo
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+)
+
+func assertSeriesValues(t *testing.T, name string, got TimeSeries, want []float64) {
+	t.Helper()
+	if got.Length() != len(want) {
+		t.Fatalf("%s: expected %d points, got %d", name, len(want), got.Length())
+	}
+	for i, v := range got.Values() {
+		if math.IsNaN(want[i]) != math.IsNaN(v) || (!math.IsNaN(v) && math.Abs(v-want[i]) > 1e-9) {
+			t.Errorf("%s idx %d: expected %v, got %v", name, i, want[i], v)
+		}
+	}
+}
+
+func TestRollingCountWindow(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{1, 5, 2, 4, 3})
+	nan := math.NaN()
+
+	r := ts.Rolling(CountWindow(3))
+	assertSeriesValues(t, "sum", r.Sum(), []float64{1, 6, 8, 11, 9})
+	assertSeriesValues(t, "mean", r.Mean(), []float64{1, 3, 8.0 / 3, 11.0 / 3, 3})
+	assertSeriesValues(t, "min", r.Min(), []float64{1, 1, 1, 2, 2})
+	assertSeriesValues(t, "max", r.Max(), []float64{1, 5, 5, 5, 4})
+	assertSeriesValues(t, "count", r.Count(), []float64{1, 2, 3, 3, 3})
+	assertSeriesValues(t, "var", r.Var(), []float64{nan, 8, 13.0 / 3, 7.0 / 3, 1})
+
+	filling := ts.Rolling(CountWindow(3).WithMinPeriods(3))
+	assertSeriesValues(t, "min periods", filling.Sum(), []float64{nan, nan, 8, 11, 9})
+
+	centered := ts.Rolling(CountWindow(3).Centered())
+	assertSeriesValues(t, "centered", centered.Sum(), []float64{6, 8, 11, 9, 7})
+	even := ts.Rolling(CountWindow(4).Centered())
+	// Rows i-2..i+1, like pandas.
+	assertSeriesValues(t, "centered even", even.Sum(), []float64{6, 8, 12, 14, 9})
+}
+
+func TestRollingTimeWindow(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 5, 6, 7}, []float64{1, 2, 3, 4, 5})
+	ts.SetLabel("requests")
+
+	r := ts.Rolling(TimeWindow(2 * time.Minute))
+	sums := r.Sum()
+	assertSeriesValues(t, "trailing", sums, []float64{1, 3, 3, 7, 9})
+	if sums.Label() != "requests" {
+		t.Errorf("expected metadata to be kept")
+	}
+
+	centered := ts.Rolling(TimeWindow(4 * time.Minute).Centered())
+	// (t-2m, t+2m]
+	assertSeriesValues(t, "centered", centered.Sum(), []float64{3, 3, 12, 12, 9})
+}
+
+func TestRollingSkipsNaN(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3}, []float64{1, math.NaN(), 3, 4})
+	r := ts.Rolling(CountWindow(2).WithMinPeriods(2))
+	nan := math.NaN()
+	assertSeriesValues(t, "sum", r.Sum(), []float64{nan, nan, nan, 7})
+	assertSeriesValues(t, "max", ts.Rolling(CountWindow(2)).Max(), []float64{1, 1, 3, 4})
+}
+
+func TestRollingMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	at := base
+	for i := 0; i < 2000; i++ {
+		at = at.Add(time.Duration(1+rng.IntN(90)) * time.Second)
+		ts.AddPoint(DataPoint{at, rng.NormFloat64()})
+	}
+	windows := []Window{TimeWindow(10 * time.Minute), CountWindow(25), TimeWindow(7 * time.Minute).Centered(), CountWindow(10).Centered()}
+	for wi, w := range windows {
+		los, his := w.bounds(ts.times, time.UTC)
+		r := ts.Rolling(w)
+		minSeries, maxSeries, varSeries := r.Min(), r.Max(), r.Var()
+		mins, maxs, vars := minSeries.ValuesView(), maxSeries.ValuesView(), varSeries.ValuesView()
+		for i := range ts.times {
+			vs := append([]float64(nil), ts.values[los[i]:his[i]]...)
+			sort.Float64s(vs)
+			if mins[i] != vs[0] || maxs[i] != vs[len(vs)-1] {
+				t.Fatalf("window %d idx %d: min/max mismatch", wi, i)
+			}
+			if len(vs) > 1 {
+				if v := AggregateStdDev(vs); math.Abs(math.Sqrt(vars[i])-v) > 1e-6 {
+					t.Fatalf("window %d idx %d: expected stddev %v, got %v", wi, i, v, math.Sqrt(vars[i]))
+				}
+			}
+		}
+	}
+}
+
+func TestRollingWindowKeepsApplySemantics(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 0, 1, 3}, []float64{1, 2, 3, 4})
+	rolled := ts.RollingWindow(2*time.Minute, func(vs []float64) float64 { return float64(len(vs)) })
+	// Points sharing a timestamp are always in each other's window.
+	assertSeriesValues(t, "apply", rolled, []float64{2, 2, 3, 1})
+}
+
+func TestRollingApplyDoesNotExposeInput(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4}, []float64{5, 4, 3, 2, 1})
+	sortFirst := func(vs []float64) float64 {
+		sort.Float64s(vs)
+		return vs[0]
+	}
+	assertSeriesValues(t, "apply", ts.Rolling(CountWindow(3)).Apply(sortFirst), []float64{5, 4, 3, 2, 1})
+	assertSeriesValues(t, "rolling window", ts.RollingWindow(2*time.Minute, sortFirst), []float64{5, 4, 3, 2, 1})
+	assertSeriesValues(t, "input", ts, []float64{5, 4, 3, 2, 1})
+}
+
+func bruteQuantile(vs []float64, q float64) float64 {
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+	h := float64(len(sorted)-1) * q
+	lo := int(math.Floor(h))
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+func TestRollingOrderStatistics(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3, 4, 5}, []float64{5, 1, 4, 2, 3, 100})
+	r := ts.Rolling(CountWindow(4))
+	assertSeriesValues(t, "median", r.Median(), []float64{5, 3, 4, 3, 2.5, 3.5})
+	assertSeriesValues(t, "quantile", r.Quantile(0.25), []float64{5, 2, 2.5, 1.75, 1.75, 2.75})
+	// Window {5, 1, 4, 2}: median 3, deviations {2, 2, 1, 1} -> 1.5.
+	assertSeriesValues(t, "mad", r.MAD(), []float64{0, 2, 1, 1.5, 1, 1})
+	nan := math.NaN()
+	assertSeriesValues(t, "invalid q", r.Quantile(1.5), []float64{nan, nan, nan, nan, nan, nan})
+}
+
+func TestRollingOrderStatisticsMatchBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	at := base
+	for i := 0; i < 1500; i++ {
+		at = at.Add(time.Duration(1+rng.IntN(60)) * time.Second)
+		// Rounded values produce plenty of ties.
+		ts.AddPoint(DataPoint{at, math.Round(rng.NormFloat64() * 5)})
+	}
+	for wi, w := range []Window{TimeWindow(15 * time.Minute), CountWindow(30), CountWindow(11).Centered()} {
+		los, his := w.bounds(ts.times, time.UTC)
+		r := ts.Rolling(w)
+		q90Series, madSeries := r.Quantile(0.9), r.MAD()
+		q90, mad := q90Series.ValuesView(), madSeries.ValuesView()
+		for i := range ts.times {
+			vs := ts.values[los[i]:his[i]]
+			if want := bruteQuantile(vs, 0.9); math.Abs(q90[i]-want) > 1e-9 {
+				t.Fatalf("window %d idx %d: expected q90 %v, got %v", wi, i, want, q90[i])
+			}
+			m := bruteQuantile(vs, 0.5)
+			devs := make([]float64, len(vs))
+			for k, v := range vs {
+				devs[k] = math.Abs(v - m)
+			}
+			if want := bruteQuantile(devs, 0.5); math.Abs(mad[i]-want) > 1e-9 {
+				t.Fatalf("window %d idx %d: expected MAD %v, got %v", wi, i, want, mad[i])
+			}
+		}
+	}
+}
+
+func TestOrderStatsMemoryFollowsWindow(t *testing.T) {
+	o := newOrderStats()
+	for i := 0; i < 1000; i++ {
+		o.update(float64(i%7), 1)
+		if i >= 10 {
+			o.update(float64((i-10)%7), -1)
+		}
+	}
+	if o.size() != 10 {
+		t.Fatalf("expected 10 values, got %d", o.size())
+	}
+	if len(o.nodes) > 12 {
+		t.Errorf("expected storage for about one window, got %d nodes", len(o.nodes))
+	}
+}
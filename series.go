@@ -0,0 +1,330 @@
+package timeseriesgo
+
+import (
+	"errors"
+	"maps"
+	"sort"
+	"time"
+)
+
+// Point is a single timestamped value of any type.
+type Point[T any] struct {
+	Timestamp time.Time
+	Value     T
+}
+
+// Series is the generic core behind TimeSeries. It holds values of any type
+// (int64 counters, bool flags, string states) together with series metadata.
+// Operations that need arithmetic live on TimeSeries, the float64 instantiation.
+//
+// Storage is columnar: timestamps are kept as Unix nanoseconds next to a value slice,
+// and one location is kept for the whole series (taken from the first point added).
+// Timestamps handed out are rebuilt in that location, without monotonic clock readings,
+// so compare them with Equal. Representable instants span the years 1678 to 2262.
+type Series[T any] struct {
+	times  []int64
+	values []T
+	loc    *time.Location
+	label  string
+	tags   map[string]string
+	unit   string
+}
+
+func EmptySeries[T any](label string) Series[T] {
+	return Series[T]{times: []int64{}, values: []T{}, label: label}
+}
+
+// SeriesFromPoints builds a Series from a slice of points (copied). Order is kept as given.
+func SeriesFromPoints[T any](points []Point[T]) Series[T] {
+	s := Series[T]{times: make([]int64, 0, len(points)), values: make([]T, 0, len(points)), label: "new series"}
+	for _, p := range points {
+		s.AddPoint(p)
+	}
+	return s
+}
+
+func (s *Series[T]) IsEmpty() bool {
+	return len(s.times) == 0
+}
+
+func (s *Series[T]) Length() int {
+	return len(s.times)
+}
+
+/**
+ * Adds a Point to the Series without checks.
+ */
+func (s *Series[T]) AddPoint(p Point[T]) {
+	if s.loc == nil {
+		s.loc = p.Timestamp.Location()
+	}
+	s.times = append(s.times, p.Timestamp.UnixNano())
+	s.values = append(s.values, p.Value)
+}
+
+// Location returns the location timestamps are reported in (UTC for an empty series).
+func (s *Series[T]) Location() *time.Location {
+	if s.loc == nil {
+		return time.UTC
+	}
+	return s.loc
+}
+
+// timeAt rebuilds the i-th timestamp in the series location.
+func (s *Series[T]) timeAt(i int) time.Time {
+	return time.Unix(0, s.times[i]).In(s.Location())
+}
+
+func (s *Series[T]) pointAt(i int) Point[T] {
+	return Point[T]{Timestamp: s.timeAt(i), Value: s.values[i]}
+}
+
+// DataPoints returns the points as a freshly built slice.
+func (s *Series[T]) DataPoints() []Point[T] {
+	res := make([]Point[T], len(s.times))
+	for i := range s.times {
+		res[i] = s.pointAt(i)
+	}
+	return res
+}
+
+/**
+ * Returns a copy of the values of all points.
+ */
+func (s *Series[T]) Values() []T {
+	res := make([]T, len(s.values))
+	copy(res, s.values)
+	return res
+}
+
+/**
+ * Returns all timestamps.
+ */
+func (s *Series[T]) Timestamps() []time.Time {
+	res := make([]time.Time, len(s.times))
+	for i := range s.times {
+		res[i] = s.timeAt(i)
+	}
+	return res
+}
+
+// ValuesView returns the value column without copying. It must be treated as read-only.
+func (s *Series[T]) ValuesView() []T {
+	return s.values[:len(s.values):len(s.values)]
+}
+
+// UnixNanosView returns the timestamp column (Unix nanoseconds) without copying.
+// It must be treated as read-only.
+func (s *Series[T]) UnixNanosView() []int64 {
+	return s.times[:len(s.times):len(s.times)]
+}
+
+/**
+ * Returns the first point in the series.
+ */
+func (s *Series[T]) Head() (Point[T], error) {
+	if s.IsEmpty() {
+		return Point[T]{}, errors.New("timeSeries is empty")
+	}
+	return s.pointAt(0), nil
+}
+
+/**
+ * Returns the last point in the series.
+ */
+func (s *Series[T]) Last() (Point[T], error) {
+	if s.IsEmpty() {
+		return Point[T]{}, errors.New("timeSeries is empty")
+	}
+	return s.pointAt(len(s.times) - 1), nil
+}
+
+/**
+ * Returns the series without the first point.
+ */
+func (s *Series[T]) Tail() Series[T] {
+	if s.IsEmpty() {
+		return s.derive()
+	}
+	return s.sub(1, len(s.times))
+}
+
+/**
+ * Slices the sorted Series to [start, end) using binary search.
+ */
+func (s *Series[T]) Slice(start time.Time, end time.Time) Series[T] {
+	lo := s.searchIndex(start)
+	hi := s.searchIndex(end)
+	if lo >= hi {
+		return s.derive()
+	}
+	return s.sub(lo, hi)
+}
+
+// sub copies the points in [lo, hi) into a new series with the same metadata.
+func (s *Series[T]) sub(lo, hi int) Series[T] {
+	res := s.derive()
+	res.times = append(make([]int64, 0, hi-lo), s.times[lo:hi]...)
+	res.values = append(make([]T, 0, hi-lo), s.values[lo:hi]...)
+	return res
+}
+
+/**
+ * Maps over the full Point, keeping the value type.
+ */
+func (s *Series[T]) Map(f func(Point[T]) Point[T]) Series[T] {
+	res := s.derive()
+	res.times = make([]int64, 0, len(s.times))
+	res.values = make([]T, 0, len(s.values))
+	for i := range s.times {
+		res.AddPoint(f(s.pointAt(i)))
+	}
+	return res
+}
+
+/**
+ * Keeps the points that satisfy the predicate.
+ */
+func (s *Series[T]) Filter(f func(Point[T]) bool) Series[T] {
+	res := s.derive()
+	for i := range s.times {
+		if p := s.pointAt(i); f(p) {
+			res.times = append(res.times, s.times[i])
+			res.values = append(res.values, p.Value)
+		}
+	}
+	return res
+}
+
+/**
+ * Converts every value to another type, keeping timestamps and metadata.
+ * The timestamp column is shared with the input rather than copied.
+ */
+func MapSeries[T, U any](s Series[T], f func(T) U) Series[U] {
+	res := Series[U]{times: s.UnixNanosView(), values: make([]U, len(s.values)), loc: s.loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+	for i, v := range s.values {
+		res.values[i] = f(v)
+	}
+	return res
+}
+
+/**
+ * Groups points by a time key and reduces every group to one value.
+ * Groups are emitted in order of first appearance; keys are matched by instant through a map,
+ * so the cost is linear in the number of points.
+ *
+ * @param g Maps a timestamp to its group key (e.g. truncation to the hour).
+ * @param f Reduces the points of one group.
+ */
+func GroupSeriesByTime[T, U any](s Series[T], g func(time.Time) time.Time, f func([]Point[T]) U) Series[U] {
+	res := Series[U]{times: []int64{}, values: []U{}, loc: s.loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+	var keys []time.Time
+	var groups [][]Point[T]
+	index := make(map[int64]int)
+	for i := range s.times {
+		p := s.pointAt(i)
+		key := g(p.Timestamp)
+		idx, ok := index[key.UnixNano()]
+		if !ok {
+			index[key.UnixNano()] = len(keys)
+			keys = append(keys, key)
+			groups = append(groups, []Point[T]{p})
+		} else {
+			groups[idx] = append(groups[idx], p)
+		}
+	}
+	for i, group := range groups {
+		res.AddPoint(Point[U]{Timestamp: keys[i], Value: f(group)})
+	}
+	return res
+}
+
+// Pair holds the two values matched by a join.
+type Pair[L, R any] struct {
+	Left  L
+	Right R
+}
+
+/**
+ * Joins (inner) two sorted series of any value types on equal timestamps.
+ */
+func JoinSeries[L, R any](left Series[L], right Series[R]) Series[Pair[L, R]] {
+	res := EmptySeries[Pair[L, R]](left.label + " joined with " + right.label)
+	res.loc = left.loc
+	var noL L
+	var noR R
+	mergeJoinSeries(&left, &right, false, false, noL, noR, func(t int64, l L, r R) {
+		res.times = append(res.times, t)
+		res.values = append(res.values, Pair[L, R]{Left: l, Right: r})
+	})
+	return res
+}
+
+/**
+ * Joins (left) two sorted series of any value types, using defaultValue for missing right values.
+ */
+func JoinSeriesLeft[L, R any](left Series[L], right Series[R], defaultValue R) Series[Pair[L, R]] {
+	res := EmptySeries[Pair[L, R]](left.label + " joined with " + right.label)
+	res.loc = left.loc
+	var noL L
+	mergeJoinSeries(&left, &right, true, false, noL, defaultValue, func(t int64, l L, r R) {
+		res.times = append(res.times, t)
+		res.values = append(res.values, Pair[L, R]{Left: l, Right: r})
+	})
+	return res
+}
+
+// mergeJoinSeries walks two sorted series once. Timestamps present on both sides produce the
+// cross product of the equal runs (left-major), like SQL joins on duplicate keys. Unmatched
+// points are kept with the opposite default when keepLeft/keepRight is set.
+func mergeJoinSeries[L, R any](left *Series[L], right *Series[R], keepLeft, keepRight bool, defaultLeft L, defaultRight R, emit func(int64, L, R)) {
+	lt, rt := left.times, right.times
+	i, j := 0, 0
+	for i < len(lt) || j < len(rt) {
+		switch {
+		case j == len(rt) || (i < len(lt) && lt[i] < rt[j]):
+			if keepLeft {
+				emit(lt[i], left.values[i], defaultRight)
+			}
+			i++
+		case i == len(lt) || rt[j] < lt[i]:
+			if keepRight {
+				emit(rt[j], defaultLeft, right.values[j])
+			}
+			j++
+		default:
+			iEnd, jEnd := i+1, j+1
+			for iEnd < len(lt) && lt[iEnd] == lt[i] {
+				iEnd++
+			}
+			for jEnd < len(rt) && rt[jEnd] == rt[j] {
+				jEnd++
+			}
+			for a := i; a < iEnd; a++ {
+				for b := j; b < jEnd; b++ {
+					emit(lt[a], left.values[a], right.values[b])
+				}
+			}
+			i, j = iEnd, jEnd
+		}
+	}
+}
+
+// appendRaw appends an already converted timestamp and value.
+func (s *Series[T]) appendRaw(t int64, v T) {
+	s.times = append(s.times, t)
+	s.values = append(s.values, v)
+}
+
+// searchIndex returns the index of the first point at or after t.
+func (s *Series[T]) searchIndex(t time.Time) int {
+	n := t.UnixNano()
+	return sort.Search(len(s.times), func(i int) bool {
+		return s.times[i] >= n
+	})
+}
+
+// derive returns an empty series carrying the receiver's location and a copy of its metadata.
+func (s *Series[T]) derive() Series[T] {
+	return Series[T]{times: []int64{}, values: []T{}, loc: s.loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+}
@@ -0,0 +1,111 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeriesHoldsNonFloatValues(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	counter := EmptySeries[int64]("bytes")
+	big := int64(1) << 60
+	counter.AddPoint(Point[int64]{base, big})
+	counter.AddPoint(Point[int64]{base.Add(time.Minute), big + 1})
+
+	vs := counter.Values()
+	if vs[1]-vs[0] != 1 {
+		t.Errorf("int64 values lost precision: %v", vs)
+	}
+
+	sliced := counter.Slice(base.Add(time.Second), base.Add(time.Hour))
+	if sliced.Length() != 1 || sliced.Label() != "bytes" {
+		t.Errorf("unexpected slice %+v", sliced.DataPoints())
+	}
+
+	states := EmptySeries[string]("status")
+	states.AddPoint(Point[string]{base, "up"})
+	states.AddPoint(Point[string]{base.Add(time.Minute), "down"})
+	down := states.Filter(func(p Point[string]) bool { return p.Value == "down" })
+	if down.Length() != 1 || !down.DataPoints()[0].Timestamp.Equal(base.Add(time.Minute)) {
+		t.Errorf("unexpected filter result %+v", down.DataPoints())
+	}
+
+	isUp := MapSeries(states, func(s string) bool { return s == "up" })
+	if got := isUp.Values(); !got[0] || got[1] {
+		t.Errorf("unexpected mapped values %v", got)
+	}
+}
+
+func TestGenericJoinAndGroup(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	states := SeriesFromPoints([]Point[string]{
+		{base, "up"},
+		{base.Add(30 * time.Minute), "up"},
+		{base.Add(time.Hour), "down"},
+	})
+	flags := SeriesFromPoints([]Point[bool]{
+		{base, true},
+		{base.Add(time.Hour), false},
+	})
+
+	joined := JoinSeries(states, flags)
+	if joined.Length() != 2 {
+		t.Fatalf("expected 2 joined points, got %d", joined.Length())
+	}
+	if p := joined.DataPoints()[1].Value; p.Left != "down" || p.Right {
+		t.Errorf("unexpected pair %+v", p)
+	}
+
+	left := JoinSeriesLeft(states, flags, false)
+	if left.Length() != 3 {
+		t.Errorf("expected 3 left-joined points, got %d", left.Length())
+	}
+
+	counts := GroupSeriesByTime(states, roundToHour, func(ps []Point[string]) int { return len(ps) })
+	if got := counts.Values(); len(got) != 2 || got[0] != 2 || got[1] != 1 {
+		t.Errorf("unexpected group counts %v", got)
+	}
+}
+
+func TestTimeSeriesIsFloatSeries(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := EmptyLabeled("cpu")
+	ts.AddPoint(DataPoint{base, 1.5})
+
+	s := ts.AsSeries()
+	s.AddPoint(Point[float64]{base.Add(time.Minute), 2.5})
+	back := FromSeries(s)
+	if back.Length() != 2 || back.Sum() != 4 || back.Label() != "cpu" {
+		t.Errorf("unexpected round trip %+v", back.DataPoints())
+	}
+}
+
+func TestColumnarViewsAndLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, loc)
+	ts := EmptyLabeled("cpu")
+	ts.AddPoint(DataPoint{base, 1})
+	ts.AddPoint(DataPoint{base.Add(time.Minute), 2})
+
+	nanos := ts.UnixNanosView()
+	if len(nanos) != 2 || nanos[1]-nanos[0] != int64(time.Minute) {
+		t.Errorf("unexpected timestamp column %v", nanos)
+	}
+	if vs := ts.ValuesView(); vs[0] != 1 || vs[1] != 2 {
+		t.Errorf("unexpected value column %v", vs)
+	}
+
+	first, _ := ts.Head()
+	if first.Timestamp.Location() != loc || !first.Timestamp.Equal(base) {
+		t.Errorf("expected timestamp in series location, got %v", first.Timestamp)
+	}
+
+	// Value-only transforms share the timestamp column; appending to either side must not leak.
+	doubled := ts.MapValues(func(v float64) float64 { return v * 2 })
+	doubled.AddPoint(DataPoint{base.Add(2 * time.Minute), 6})
+	ts.AddPoint(DataPoint{base.Add(3 * time.Minute), 3})
+	last, _ := doubled.Last()
+	if !last.Timestamp.Equal(base.Add(2*time.Minute)) || last.Value != 6 {
+		t.Errorf("shared column leaked between series: %+v", last)
+	}
+}
@@ -0,0 +1,88 @@
+package timeseriesgo
+
+import (
+	"maps"
+	"math"
+	"time"
+)
+
+/**
+ * Shifts the values by n positions, keeping the timestamps: a positive n lags (each point
+ * takes the value n points earlier), a negative n leads. Positions without a source are NaN.
+ */
+func (ts *TimeSeries) Shift(n int) TimeSeries {
+	res := ts.derive()
+	for i, t := range ts.times {
+		v := math.NaN()
+		if j := i - n; j >= 0 && j < len(ts.values) {
+			v = ts.values[j]
+		}
+		res.appendRaw(t, v)
+	}
+	return res
+}
+
+/**
+ * Like Shift, but drops the points that would be NaN-filled, so the result is |n| points shorter.
+ */
+func (ts *TimeSeries) ShiftDrop(n int) TimeSeries {
+	res := ts.derive()
+	for i, t := range ts.times {
+		if j := i - n; j >= 0 && j < len(ts.values) {
+			res.appendRaw(t, ts.values[j])
+		}
+	}
+	return res
+}
+
+/**
+ * Moves every timestamp by d, keeping the values. The value columns are shared.
+ */
+func (s *Series[T]) ShiftTime(d time.Duration) Series[T] {
+	times := make([]int64, len(s.times))
+	for i, t := range s.times {
+		times[i] = t + int64(d)
+	}
+	return Series[T]{times: times, values: s.ValuesView(), loc: s.loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+}
+
+/**
+ * Moves every timestamp by n periods of freq on the series' wall clock, e.g. MonthStart(1)
+ * moves Jan 31 to Feb 29 and Days(7) keeps local midnight across DST changes.
+ * The result is sorted when the input is and freq preserves order.
+ */
+func (s *Series[T]) ShiftTimeWithFrequency(freq Frequency, n int) Series[T] {
+	times := make([]int64, len(s.times))
+	for i := range s.times {
+		times[i] = freq.Shift(s.timeAt(i), n).UnixNano()
+	}
+	return Series[T]{times: times, values: s.ValuesView(), loc: s.loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+}
+
+// ShiftTime is Series.ShiftTime for float64 values.
+func (ts *TimeSeries) ShiftTime(d time.Duration) TimeSeries {
+	return TimeSeries(ts.core().ShiftTime(d))
+}
+
+// ShiftTimeWithFrequency is Series.ShiftTimeWithFrequency for float64 values.
+func (ts *TimeSeries) ShiftTimeWithFrequency(freq Frequency, n int) TimeSeries {
+	return TimeSeries(ts.core().ShiftTimeWithFrequency(freq, n))
+}
+
+/**
+ * Joins the series with its own copy shifted d later, for period-over-period comparisons.
+ * Example: ts.JoinShifted(7*24*time.Hour).MapValuesWithReduce(func(now, weekAgo float64) float64 { return now - weekAgo })
+ *
+ * @return An AlignedSeries with the current value on the left and the value from d earlier
+ *         on the right, for the timestamps that have both.
+ */
+func (ts *TimeSeries) JoinShifted(d time.Duration) AlignedSeries {
+	return ts.Join(ts.ShiftTime(d))
+}
+
+/**
+ * Like JoinShifted, with the lag given as n periods of freq, e.g. YearStart(1) for year over year.
+ */
+func (ts *TimeSeries) JoinShiftedWithFrequency(freq Frequency, n int) AlignedSeries {
+	return ts.Join(ts.ShiftTimeWithFrequency(freq, n))
+}
@@ -0,0 +1,58 @@
+package timeseriesgo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestShift(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1, 2, 3}, []float64{1, 2, 3, 4})
+	nan := math.NaN()
+	assertSeriesValues(t, "lag", ts.Shift(1), []float64{nan, 1, 2, 3})
+	assertSeriesValues(t, "lead", ts.Shift(-2), []float64{3, 4, nan, nan})
+	assertSeriesValues(t, "none", ts.Shift(0), []float64{1, 2, 3, 4})
+	assertSeriesValues(t, "too far", ts.Shift(5), []float64{nan, nan, nan, nan})
+
+	dropped := ts.ShiftDrop(1)
+	assertSeriesValues(t, "drop", dropped, []float64{1, 2, 3})
+	if first := dropped.Timestamps()[0]; !first.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected the first point at 00:01, got %v", first)
+	}
+	lead := ts.ShiftDrop(-1)
+	assertSeriesValues(t, "drop lead", lead, []float64{2, 3, 4})
+}
+
+func TestShiftTime(t *testing.T) {
+	base := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	ts := minuteSeries(base, []int{0, 1}, []float64{1, 2})
+	moved := ts.ShiftTime(time.Hour)
+	if got := moved.Timestamps()[1]; !got.Equal(base.Add(61 * time.Minute)) {
+		t.Errorf("expected 01:01, got %v", got)
+	}
+	assertSeriesValues(t, "values kept", moved, []float64{1, 2})
+
+	monthly := ts.ShiftTimeWithFrequency(MonthStart(1), 1)
+	if got := monthly.Timestamps()[0]; !got.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected Feb 29, got %v", got)
+	}
+}
+
+func TestJoinShifted(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := Empty()
+	for i, v := range []float64{10, 12, 15, 11} {
+		ts.AddPoint(DataPoint{base.AddDate(0, 0, 7*i), v})
+	}
+	aligned := ts.JoinShifted(7 * 24 * time.Hour)
+	if aligned.Length() != 3 {
+		t.Fatalf("expected 3 aligned points, got %d", aligned.Length())
+	}
+	delta := aligned.MapValuesWithReduce(func(now, before float64) float64 { return now - before })
+	assertSeriesValues(t, "week over week", delta, []float64{2, 3, -4})
+
+	weekly := ts.JoinShiftedWithFrequency(Days(7), 2)
+	ratio := weekly.MapValuesWithReduce(func(now, before float64) float64 { return now / before })
+	assertSeriesValues(t, "two weeks", ratio, []float64{1.5, 11.0 / 12})
+}
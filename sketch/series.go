@@ -0,0 +1,124 @@
+package sketch
+
+import (
+	"slices"
+	"time"
+
+	timeseriesgo "github.com/wenta/timeseries-go"
+)
+
+/**
+ * Summarises all values of a series in one digest.
+ *
+ * @param compression See NewTDigest.
+ */
+func FromTimeSeries(ts timeseriesgo.TimeSeries, compression float64) *TDigest {
+	d := NewTDigest(compression)
+	for _, v := range ts.All() {
+		d.Add(v)
+	}
+	return d
+}
+
+/**
+ * Builds one digest per time group in a single pass, like TimeSeries.GroupByTime but without
+ * keeping the values of a group: memory is bounded by the number of groups times the digest size.
+ * Groups are emitted in order of first appearance.
+ *
+ * @param g Maps a timestamp to its group key, e.g. truncation to the hour.
+ * @param compression See NewTDigest.
+ *
+ * @return A series of digests stamped with the group keys, carrying the metadata of ts.
+ */
+func GroupByTime(ts timeseriesgo.TimeSeries, g func(time.Time) time.Time, compression float64) timeseriesgo.Series[*TDigest] {
+	var keys []time.Time
+	var digests []*TDigest
+	index := make(map[int64]int)
+	for t, v := range ts.All() {
+		key := g(t)
+		idx, ok := index[key.UnixNano()]
+		if !ok {
+			idx = len(keys)
+			index[key.UnixNano()] = idx
+			keys = append(keys, key)
+			digests = append(digests, NewTDigest(compression))
+		}
+		digests[idx].Add(v)
+	}
+	res := timeseriesgo.EmptySeries[*TDigest](ts.Label())
+	res.SetMetadata(ts.Metadata())
+	for i, key := range keys {
+		res.AddPoint(timeseriesgo.Point[*TDigest]{Timestamp: key, Value: digests[i]})
+	}
+	return res
+}
+
+/**
+ * Merges digest series from several sources (e.g. one per host) by timestamp.
+ * The inputs are not changed; the result is sorted and has one fresh digest per timestamp.
+ */
+func MergeByTime(series ...timeseriesgo.Series[*TDigest]) timeseriesgo.Series[*TDigest] {
+	index := make(map[int64]*TDigest)
+	var times []time.Time
+	for _, s := range series {
+		for t, d := range s.All() {
+			merged, ok := index[t.UnixNano()]
+			if !ok {
+				merged = NewTDigest(d.compression)
+				index[t.UnixNano()] = merged
+				times = append(times, t)
+			}
+			merged.Merge(d)
+		}
+	}
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	res := timeseriesgo.EmptySeries[*TDigest]("merged")
+	for _, t := range times {
+		res.AddPoint(timeseriesgo.Point[*TDigest]{Timestamp: t, Value: index[t.UnixNano()]})
+	}
+	return res
+}
+
+/**
+ * Estimates the q-quantile of every digest, e.g. hourly p99 from GroupByTime.
+ */
+func Quantile(digests timeseriesgo.Series[*TDigest], q float64) timeseriesgo.TimeSeries {
+	return timeseriesgo.FromSeries(timeseriesgo.MapSeries(digests, func(d *TDigest) float64 {
+		return d.Quantile(q)
+	}))
+}
+
+/**
+ * Estimates the q-quantile over a rolling window of the last n digests (the current one
+ * included), by merging them at DefaultCompression. Pre-aggregating into buckets with GroupByTime keeps this
+ * bounded for any data rate, e.g. a 1-hour p99 sliding by minute from minutely digests
+ * with n = 60. n below 1 is treated as 1. Windows with no values give NaN.
+ */
+func RollingQuantile(digests timeseriesgo.Series[*TDigest], n int, q float64) timeseriesgo.TimeSeries {
+	n = max(n, 1)
+	ds := digests.ValuesView()
+	res := timeseriesgo.EmptyLabeled(digests.Label())
+	res.SetMetadata(digests.Metadata())
+	for i, t := range digests.Timestamps() {
+		window := NewTDigest(0)
+		for _, d := range ds[max(i-n+1, 0) : i+1] {
+			window.Merge(d)
+		}
+		res.AddPoint(timeseriesgo.DataPoint{Timestamp: t, Value: window.Quantile(q)})
+	}
+	return res
+}
+
+/**
+ * Returns an aggregator estimating the q-quantile of a bucket through a digest, for
+ * Downsample, GroupByPeriod or Rolling(...).Apply where an exact sort of every bucket is too slow.
+ */
+func QuantileAggregator(q, compression float64) timeseriesgo.Aggregator {
+	return func(vs []float64) float64 {
+		d := NewTDigest(compression)
+		for _, v := range vs {
+			d.Add(v)
+		}
+		return d.Quantile(q)
+	}
+}
@@ -0,0 +1,80 @@
+package sketch
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	timeseriesgo "github.com/wenta/timeseries-go"
+)
+
+func secondly(base time.Time, n int, value func(i int) float64) timeseriesgo.TimeSeries {
+	ts := timeseriesgo.EmptyLabeled("latency")
+	for i := 0; i < n; i++ {
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: base.Add(time.Duration(i) * time.Second), Value: value(i)})
+	}
+	return ts
+}
+
+func TestGroupByTimeQuantiles(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	// Every minute holds the values 0..59.
+	ts := secondly(base, 180, func(i int) float64 { return float64(i % 60) })
+	minutely := GroupByTime(ts, func(t time.Time) time.Time { return t.Truncate(time.Minute) }, 0)
+	if minutely.Length() != 3 || minutely.Label() != "latency" {
+		t.Fatalf("expected 3 labelled groups, got %d %q", minutely.Length(), minutely.Label())
+	}
+	p50 := Quantile(minutely, 0.5)
+	for _, v := range p50.Values() {
+		if math.Abs(v-29.5) > 0.5 {
+			t.Errorf("expected a median near 29.5, got %v", v)
+		}
+	}
+
+	rolling := RollingQuantile(minutely, 2, 1)
+	if got := rolling.Values(); got[0] != 59 || got[2] != 59 {
+		t.Errorf("expected rolling max 59, got %v", got)
+	}
+
+	whole := FromTimeSeries(ts, 0)
+	if whole.Count() != 180 || whole.Max() != 59 {
+		t.Errorf("expected 180 values up to 59, got %v and %v", whole.Count(), whole.Max())
+	}
+}
+
+func TestMergeByTime(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	hour := func(t time.Time) time.Time { return t.Truncate(time.Hour) }
+	web1 := GroupByTime(secondly(base, 100, func(int) float64 { return 10 }), hour, 0)
+	web2 := GroupByTime(secondly(base.Add(time.Hour), 100, func(int) float64 { return 20 }), hour, 0)
+	web3 := GroupByTime(secondly(base, 100, func(int) float64 { return 30 }), hour, 0)
+
+	merged := MergeByTime(web2, web1, web3)
+	if merged.Length() != 2 {
+		t.Fatalf("expected 2 hours, got %d", merged.Length())
+	}
+	counts := []float64{200, 100}
+	for i, d := range merged.ValuesView() {
+		if d.Count() != counts[i] {
+			t.Errorf("hour %d: expected %v values, got %v", i, counts[i], d.Count())
+		}
+	}
+	top := Quantile(merged, 1)
+	maxima := top.Values()
+	if maxima[0] != 30 || maxima[1] != 20 {
+		t.Errorf("expected maxima 30 and 20, got %v", maxima)
+	}
+	if web1.ValuesView()[0].Count() != 100 {
+		t.Error("expected the inputs to be unchanged")
+	}
+}
+
+func TestQuantileAggregator(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts := secondly(base, 120, func(i int) float64 { return float64(i) })
+	p90 := ts.Downsample(timeseriesgo.Every(time.Minute), QuantileAggregator(0.9, 0), timeseriesgo.ResampleOptions{})
+	got := p90.Values()
+	if math.Abs(got[0]-53.5) > 1 || math.Abs(got[1]-113.5) > 1 {
+		t.Errorf("expected p90 near 53.5 and 113.5, got %v", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Package sketch provides mergeable quantile sketches for series too long to sort
+// or spread over several hosts.
+package sketch
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultCompression is used when a non-positive compression is given. It keeps about
+// 100-200 centroids, a few kilobytes per digest.
+const DefaultCompression = 100
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a merging t-digest (Dunning & Ertl): a sorted list of weighted centroids that
+// are small near the extremes and large around the median. Memory is bounded by the
+// compression δ, not by the number of values, and two digests merge into one that
+// summarises both inputs.
+//
+// Accuracy: the rank error of a quantile estimate is typically well under 1/δ (under 1%
+// for the default δ = 100) and shrinks towards q(1-q) in the tails, so p99 and p999 are
+// much more precise than p50. Min, max and quantiles of up to a few values are exact.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min, max    float64
+}
+
+/**
+ * Creates an empty digest.
+ *
+ * @param compression δ, trading memory for accuracy; 0 or less means DefaultCompression.
+ */
+func NewTDigest(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = DefaultCompression
+	}
+	return &TDigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// Add adds one value; NaN is ignored.
+func (d *TDigest) Add(v float64) {
+	d.AddWeighted(v, 1)
+}
+
+// AddWeighted adds a value that occurred weight times; NaN values and non-positive weights are ignored.
+func (d *TDigest) AddWeighted(v, weight float64) {
+	if math.IsNaN(v) || !(weight > 0) {
+		return
+	}
+	d.buffer = append(d.buffer, centroid{v, weight})
+	d.count += weight
+	d.min = math.Min(d.min, v)
+	d.max = math.Max(d.max, v)
+	if len(d.buffer) >= d.bufferSize() {
+		d.compress()
+	}
+}
+
+// Merge adds everything summarised by other to d. other is not changed.
+func (d *TDigest) Merge(other *TDigest) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	d.buffer = append(d.buffer, other.centroids...)
+	d.buffer = append(d.buffer, other.buffer...)
+	d.count += other.count
+	d.min = math.Min(d.min, other.min)
+	d.max = math.Max(d.max, other.max)
+	d.compress()
+}
+
+// Count returns the total weight added.
+func (d *TDigest) Count() float64 {
+	return d.count
+}
+
+// Min returns the smallest value added, or NaN for an empty digest.
+func (d *TDigest) Min() float64 {
+	if d.count == 0 {
+		return math.NaN()
+	}
+	return d.min
+}
+
+// Max returns the largest value added, or NaN for an empty digest.
+func (d *TDigest) Max() float64 {
+	if d.count == 0 {
+		return math.NaN()
+	}
+	return d.max
+}
+
+/**
+ * Estimates the q-quantile (0 <= q <= 1) by interpolating between centroid means,
+ * and between the extreme centroids and the exact min and max.
+ * Returns NaN for an empty digest or q outside [0, 1].
+ */
+func (d *TDigest) Quantile(q float64) float64 {
+	if d.count == 0 || !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	d.compress()
+	cs := d.centroids
+	if q == 0 || len(cs) == 1 && cs[0].weight == 1 {
+		return d.min
+	}
+	if q == 1 {
+		return d.max
+	}
+	index := q * d.count
+	first, last := cs[0], cs[len(cs)-1]
+	if index < first.weight/2 {
+		return d.min + (first.mean-d.min)*index/(first.weight/2)
+	}
+	// Each centroid's mean sits at the middle of its weight.
+	seen := first.weight / 2
+	for i := 0; i+1 < len(cs); i++ {
+		step := (cs[i].weight + cs[i+1].weight) / 2
+		if seen+step > index {
+			return cs[i].mean + (cs[i+1].mean-cs[i].mean)*(index-seen)/step
+		}
+		seen += step
+	}
+	return last.mean + (d.max-last.mean)*math.Min((index-seen)/(last.weight/2), 1)
+}
+
+// Centroids returns the number of centroids kept, a measure of the digest's memory.
+func (d *TDigest) Centroids() int {
+	d.compress()
+	return len(d.centroids)
+}
+
+func (d *TDigest) bufferSize() int {
+	return int(5 * d.compression)
+}
+
+// k is the scale function k1: centroids may span at most one unit of k, which keeps them
+// small where q is near 0 or 1.
+func (d *TDigest) k(q float64) float64 {
+	return d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (d *TDigest) kInverse(k float64) float64 {
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
+
+// compress merges the buffer into the centroids in one sorted pass.
+func (d *TDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.buffer, d.centroids...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+	merged := make([]centroid, 0, len(d.centroids)+8)
+	cur := all[0]
+	seen := 0.0
+	limit := d.kInverse(d.k(0)+1) * d.count
+	for _, c := range all[1:] {
+		if seen+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		seen += cur.weight
+		limit = d.kInverse(d.k(seen/d.count)+1) * d.count
+		cur = c
+	}
+	d.centroids = append(merged, cur)
+	d.buffer = d.buffer[:0]
+}
@@ -0,0 +1,85 @@
+package sketch
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"testing"
+)
+
+// rankError returns how far the rank of estimate is from q, as a fraction of the sample.
+func rankError(sorted []float64, estimate, q float64) float64 {
+	rank := float64(sort.SearchFloat64s(sorted, estimate)) / float64(len(sorted))
+	return math.Abs(rank - q)
+}
+
+func TestTDigestAccuracy(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	d := NewTDigest(0)
+	values := make([]float64, 100000)
+	for i := range values {
+		values[i] = math.Exp(rng.NormFloat64()) // skewed, like latencies
+		d.Add(values[i])
+	}
+	sort.Float64s(values)
+	for _, c := range []struct{ q, tolerance float64 }{{0.5, 0.01}, {0.95, 0.005}, {0.99, 0.002}, {0.999, 0.0005}} {
+		if e := rankError(values, d.Quantile(c.q), c.q); e > c.tolerance {
+			t.Errorf("q=%v: rank error %v above %v", c.q, e, c.tolerance)
+		}
+	}
+	if d.Min() != values[0] || d.Max() != values[len(values)-1] {
+		t.Errorf("expected exact extremes, got %v and %v", d.Min(), d.Max())
+	}
+	if d.Centroids() > 2*DefaultCompression {
+		t.Errorf("expected bounded memory, got %d centroids", d.Centroids())
+	}
+	if d.Count() != 100000 {
+		t.Errorf("expected count 100000, got %v", d.Count())
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	a, b := NewTDigest(200), NewTDigest(200)
+	var values []float64
+	for i := 0; i < 50000; i++ {
+		v := rng.Float64() * 1000
+		values = append(values, v)
+		if i%2 == 0 {
+			a.Add(v)
+		} else {
+			b.Add(v + 1000)
+			values[len(values)-1] += 1000
+		}
+	}
+	a.Merge(b)
+	sort.Float64s(values)
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		if e := rankError(values, a.Quantile(q), q); e > 0.005 {
+			t.Errorf("q=%v: rank error %v after merge", q, e)
+		}
+	}
+	if b.Count() != 25000 {
+		t.Errorf("expected the merged digest to be unchanged, count %v", b.Count())
+	}
+}
+
+func TestTDigestSmall(t *testing.T) {
+	d := NewTDigest(0)
+	if !math.IsNaN(d.Quantile(0.5)) || !math.IsNaN(d.Min()) {
+		t.Error("expected NaN for an empty digest")
+	}
+	d.Add(7)
+	d.Add(math.NaN())
+	if d.Quantile(0.5) != 7 || d.Count() != 1 {
+		t.Errorf("expected 7 from one value, got %v", d.Quantile(0.5))
+	}
+	d.Add(1)
+	d.Add(4)
+	if d.Quantile(0) != 1 || d.Quantile(1) != 7 || d.Quantile(0.5) != 4 {
+		t.Errorf("expected exact quantiles for three values, got %v %v %v", d.Quantile(0), d.Quantile(0.5), d.Quantile(1))
+	}
+	if !math.IsNaN(d.Quantile(2)) {
+		t.Error("expected NaN for q > 1")
+	}
+}
@@ -25,7 +25,7 @@ func GetMeanAndVariance(ts timeseriesgo.TimeSeries) (MeanAndVariance, error) {
 
 	mean := ts.Sum() / float64(ts.Length())
 	sampleVariance := 0.0
-	for _, v := range ts.Values() {
+	for _, v := range ts.ValuesView() {
 		diff := v - mean
 		sampleVariance += diff * diff
 	}
@@ -44,35 +44,23 @@ func GetMeanAndVariance(ts timeseriesgo.TimeSeries) (MeanAndVariance, error) {
 // MovingAverage returns a rolling mean over the given time window (t-window, t].
 // If window <= 0, it returns a shallow copy of the original series.
 func MovingAverage(ts timeseriesgo.TimeSeries, window time.Duration) timeseriesgo.TimeSeries {
-	if ts.IsEmpty() {
-		return timeseriesgo.Empty()
-	}
+	return MovingAverageWithFrequency(ts, timeseriesgo.Every(window))
+}
 
-	if window <= 0 {
-		cloned := ts.DataPoints()
-		return timeseriesgo.FromDataPoints(cloned)
+// MovingAverageWithFrequency returns a rolling mean over (t - one period of freq, t],
+// e.g. the trailing calendar month for MonthStart(1). It is TimeSeries.Rolling with a time window.
+// If freq does not advance, it returns a shallow copy of the original series.
+func MovingAverageWithFrequency(ts timeseriesgo.TimeSeries, freq timeseriesgo.Frequency) timeseriesgo.TimeSeries {
+	if ts.IsEmpty() {
+		return timeseriesgo.EmptyLabeled(ts.Label())
 	}
 
-	result := timeseriesgo.Empty()
-	left := 0
-	runningSum := 0.0
-	points := ts.DataPoints()
-
-	for right, dp := range points {
-		runningSum += dp.Value
-
-		// Maintain window (t-window, t] to match RollingWindow semantics.
-		for left <= right && dp.Timestamp.Sub(points[left].Timestamp) >= window {
-			runningSum -= points[left].Value
-			left++
-		}
-
-		count := right - left + 1
-		result.AddPoint(timeseriesgo.DataPoint{
-			Timestamp: dp.Timestamp,
-			Value:     runningSum / float64(count),
-		})
+	first, _ := ts.Head()
+	if !freq.Shift(first.Timestamp, 1).After(first.Timestamp) {
+		cloned := timeseriesgo.FromDataPoints(ts.DataPoints())
+		cloned.SetMetadata(ts.Metadata())
+		return cloned
 	}
 
-	return result
+	return ts.Rolling(timeseriesgo.TimeWindowWithFrequency(freq)).Mean()
 }
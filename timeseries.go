@@ -3,102 +3,102 @@ package timeseriesgo
 import (
 	"errors"
 	"fmt"
-	"math"
 	"time"
 )
 
-type DataPoint struct {
-	Timestamp time.Time
-	Value     float64
+// DataPoint is a float64 Point, the element type of TimeSeries.
+type DataPoint = Point[float64]
+
+// TimeSeries is the float64 instantiation of Series. It shares the generic storage
+// and adds the numeric operations (statistics, resampling, joins into AlignedSeries).
+type TimeSeries Series[float64]
+
+// core views the series as its generic form without copying.
+func (ts *TimeSeries) core() *Series[float64] {
+	return (*Series[float64])(ts)
+}
+
+// AsSeries returns the series as a generic Series[float64], sharing storage.
+func (ts TimeSeries) AsSeries() Series[float64] {
+	return Series[float64](ts)
 }
 
-type TimeSeries struct {
-	datapoints []DataPoint
-	label      string
+// FromSeries wraps a generic Series[float64] as a TimeSeries, sharing storage.
+func FromSeries(s Series[float64]) TimeSeries {
+	return TimeSeries(s)
 }
 
 func Empty() TimeSeries {
-	return TimeSeries{datapoints: []DataPoint{}, label: "new series"}
+	return TimeSeries(EmptySeries[float64]("new series"))
 }
 
 func EmptyLabeled(label string) TimeSeries {
-	return TimeSeries{datapoints: []DataPoint{}, label: label}
+	return TimeSeries(EmptySeries[float64](label))
 }
 
 // FromDataPoints builds a TimeSeries from a slice of datapoints (copied).
+// The points are taken as-is; use NewBuilder or Normalize when they may be unsorted or duplicated.
 func FromDataPoints(points []DataPoint) TimeSeries {
-	cp := make([]DataPoint, len(points))
-	copy(cp, points)
-	return TimeSeries{datapoints: cp}
+	return TimeSeries(SeriesFromPoints(points))
 }
 
 func (ts *TimeSeries) IsEmpty() bool {
-	return len(ts.datapoints) == 0
+	return ts.core().IsEmpty()
 }
 
 func (ts *TimeSeries) Length() int {
-	return len(ts.datapoints)
+	return ts.core().Length()
 }
 
 /**
  * Returns the values of all points.
  */
 func (ts *TimeSeries) Values() []float64 {
-	var res []float64
-	for _, dp := range ts.datapoints {
-		res = append(res, dp.Value)
-	}
-	return res
+	return ts.core().Values()
 }
 
 /**
  * Returns all timestamps.
  */
 func (ts *TimeSeries) Timestamps() []time.Time {
-	var res []time.Time
-	for _, dp := range ts.datapoints {
-		res = append(res, dp.Timestamp)
-	}
-	return res
+	return ts.core().Timestamps()
+}
+
+// ValuesView returns the value column without copying. It must be treated as read-only.
+func (ts *TimeSeries) ValuesView() []float64 {
+	return ts.core().ValuesView()
+}
+
+// UnixNanosView returns the timestamp column (Unix nanoseconds) without copying.
+// It must be treated as read-only.
+func (ts *TimeSeries) UnixNanosView() []int64 {
+	return ts.core().UnixNanosView()
 }
 
 // DataPoints returns a shallow copy of underlying datapoints to allow safe read access.
 func (ts *TimeSeries) DataPoints() []DataPoint {
-	cp := make([]DataPoint, len(ts.datapoints))
-	copy(cp, ts.datapoints)
-	return cp
+	return ts.core().DataPoints()
 }
 
 /**
  * Returns the last point in the series.
  */
 func (ts *TimeSeries) Last() (DataPoint, error) {
-	if ts.IsEmpty() {
-		return DataPoint{}, errors.New("timeSeries is empty")
-	}
-	return ts.datapoints[len(ts.datapoints)-1], nil
+	return ts.core().Last()
 }
 
 /**
  * Returns the first point in the series.
  */
 func (ts *TimeSeries) Head() (DataPoint, error) {
-	if ts.IsEmpty() {
-		return DataPoint{}, errors.New("timeSeries is empty")
-	}
-	return ts.datapoints[0], nil
+	return ts.core().Head()
 }
 
 /**
  * Returns the series without the first point.
  */
 func (ts *TimeSeries) Tail() TimeSeries {
-	if ts.IsEmpty() {
-		return Empty()
-	}
-	cloned := make([]DataPoint, len(ts.datapoints)-1)
-	copy(cloned, ts.datapoints[1:])
-	return TimeSeries{datapoints: cloned}
+	return TimeSeries(ts.core().Tail())
 }
 
 /**
@@ -115,8 +115,8 @@ func (ts *TimeSeries) Resolution() (time.Duration, error) {
 	var modeCount int
 	counts := make(map[time.Duration]int)
 
-	for i := 1; i < len(ts.datapoints); i++ {
-		d := ts.datapoints[i].Timestamp.Sub(ts.datapoints[i-1].Timestamp)
+	for i := 1; i < len(ts.times); i++ {
+		d := time.Duration(ts.times[i] - ts.times[i-1])
 		counts[d]++
 	}
 
@@ -132,11 +132,12 @@ func (ts *TimeSeries) Resolution() (time.Duration, error) {
 
 /**
  * Adds a DataPoint to the TimeSeries.
+ * The point is appended without checks; callers adding out of order should use a Builder instead.
  *
  * @param dp The DataPoint to add.
  */
 func (ts *TimeSeries) AddPoint(dp DataPoint) {
-	ts.datapoints = append(ts.datapoints, dp)
+	ts.core().AddPoint(dp)
 }
 
 /**
@@ -144,8 +145,8 @@ func (ts *TimeSeries) AddPoint(dp DataPoint) {
  */
 func (ts *TimeSeries) Print() {
 	fmt.Println("Timestamp, Value")
-	for _, dp := range ts.datapoints {
-		fmt.Printf("%s, %.2f\n", dp.Timestamp.Format(time.RFC3339), dp.Value)
+	for i, v := range ts.values {
+		fmt.Printf("%s, %.2f\n", ts.core().timeAt(i).Format(time.RFC3339), v)
 	}
 }
 
@@ -156,43 +157,36 @@ func (ts *TimeSeries) Print() {
  * @param end The ending time.Time for the slice (exclusive).
  *
  * @return A new TimeSeries containing DataPoints within the specified time range.
+ *         The range is located with binary search, so the series must be sorted.
  */
 func (ts TimeSeries) Slice(start time.Time, end time.Time) TimeSeries {
-	sliced := Empty()
-	for _, dp := range ts.datapoints {
-		if (dp.Timestamp.Equal(start) || dp.Timestamp.After(start)) && dp.Timestamp.Before(end) {
-			sliced.AddPoint(dp)
-		}
-	}
-	return sliced
+	return TimeSeries(ts.core().Slice(start, end))
 }
 
+/**
+ * Pairs timestamps with values. Order is kept as given; see Validate.
+ */
 func Zip(timestamps []time.Time, values []float64) (TimeSeries, error) {
 	if len(timestamps) != len(values) {
 		return TimeSeries{}, errors.New("timestamps and values slices must have the same length")
 	}
 
-	points := make([]DataPoint, len(timestamps))
+	res := Series[float64]{times: make([]int64, len(timestamps)), values: make([]float64, len(values)), label: "new series"}
+	if len(timestamps) > 0 {
+		res.loc = timestamps[0].Location()
+	}
 	for i := range timestamps {
-		points[i] = DataPoint{
-			Timestamp: timestamps[i],
-			Value:     values[i],
-		}
+		res.times[i] = timestamps[i].UnixNano()
 	}
-	return TimeSeries{datapoints: points}, nil
+	copy(res.values, values)
+	return TimeSeries(res), nil
 }
 
 /**
  * Splits the series into separate slices of timestamps and values.
  */
 func (ts *TimeSeries) UnZip() ([]time.Time, []float64) {
-	timestamps := make([]time.Time, len(ts.datapoints))
-	values := make([]float64, len(ts.datapoints))
-	for i, point := range ts.datapoints {
-		timestamps[i] = point.Timestamp
-		values[i] = point.Value
-	}
-	return timestamps, values
+	return ts.Timestamps(), ts.Values()
 }
 
 /**
@@ -203,25 +197,14 @@ func (ts *TimeSeries) UnZip() ([]time.Time, []float64) {
  * @return A new TimeSeries with the function applied to each value.
  */
 func (ts *TimeSeries) MapValues(f func(float64) float64) TimeSeries {
-	mapped := Empty()
-	for _, dp := range ts.datapoints {
-		mapped.AddPoint(DataPoint{
-			Timestamp: dp.Timestamp,
-			Value:     f(dp.Value),
-		})
-	}
-	return mapped
+	return TimeSeries(MapSeries(ts.AsSeries(), f))
 }
 
 /**
  * Maps over the full DataPoint.
  */
 func (ts *TimeSeries) Map(f func(DataPoint) DataPoint) TimeSeries {
-	mapped := Empty()
-	for _, dp := range ts.datapoints {
-		mapped.AddPoint(f(dp))
-	}
-	return mapped
+	return TimeSeries(ts.core().Map(f))
 }
 
 /**
@@ -232,13 +215,7 @@ func (ts *TimeSeries) Map(f func(DataPoint) DataPoint) TimeSeries {
  * @return A new TimeSeries containing only the DataPoints that satisfy the predicate.
  */
 func (ts *TimeSeries) Filter(f func(DataPoint) bool) TimeSeries {
-	filtered := Empty()
-	for _, dp := range ts.datapoints {
-		if f(dp) {
-			filtered.AddPoint(dp)
-		}
-	}
-	return filtered
+	return TimeSeries(ts.core().Filter(f))
 }
 
 /**
@@ -252,39 +229,45 @@ func (ts *TimeSeries) Filter(f func(DataPoint) bool) TimeSeries {
  *         If delta <= 0, returns a copy. Empty input returns empty.
  */
 func (ts *TimeSeries) Resample(delta time.Duration, f func(DataPoint, DataPoint, time.Time) float64) TimeSeries {
+	return ts.ResampleWithFrequency(Every(delta), f)
+}
+
+/**
+ * Resamples the TimeSeries on the grid of a Frequency, e.g. MonthStart(1) or BusinessDays(1).
+ * The grid starts at the floor of the first timestamp; ticks before the first point have nothing to
+ * interpolate from and are skipped.
+ *
+ * @param freq The grid to resample on.
+ * @param f A function that takes the previous point, the next point, and the target timestamp, returning the interpolated value.
+ *
+ * @return A new TimeSeries as described for Resample. If freq does not advance, returns a copy.
+ *         The grid and the points are walked together, so the cost is linear in both; see Downsample
+ *         and Upsample for aggregation into buckets and the standard fill methods.
+ */
+func (ts *TimeSeries) ResampleWithFrequency(freq Frequency, f func(DataPoint, DataPoint, time.Time) float64) TimeSeries {
 	if ts.IsEmpty() {
-		return Empty()
+		return ts.derive()
 	}
-	if delta <= 0 {
-		return FromDataPoints(ts.DataPoints())
+	points := ts.DataPoints()
+	if !advances(freq, points[0].Timestamp) {
+		return ts.withPoints(points)
 	}
 
-	points := ts.DataPoints()
-	result := EmptyLabeled(ts.label + " resampled")
+	result := ts.derive()
 
-	start := points[0].Timestamp
+	start := freq.Floor(points[0].Timestamp)
 	end := points[len(points)-1].Timestamp
 
-	for t := start; !t.After(end); t = t.Add(delta) {
-		// Find bracketing points for t
-		prevIdx := -1
-		nextIdx := -1
-		for i := 0; i < len(points); i++ {
-			if points[i].Timestamp.Equal(t) {
-				result.AddPoint(points[i])
-				prevIdx = -2 // mark exact hit
-				break
-			}
-			if points[i].Timestamp.Before(t) {
-				prevIdx = i
-			} else if points[i].Timestamp.After(t) {
-				nextIdx = i
-				break
-			}
+	// j is the first point at or after the tick; ticks and points advance together.
+	j := 0
+	for t := start; !t.After(end); t = freq.Shift(t, 1) {
+		for j < len(points) && points[j].Timestamp.Before(t) {
+			j++
 		}
-
-		if prevIdx >= 0 && nextIdx >= 0 {
-			val := f(points[prevIdx], points[nextIdx], t)
+		if points[j].Timestamp.Equal(t) {
+			result.AddPoint(points[j])
+		} else if j > 0 {
+			val := f(points[j-1], points[j], t)
 			result.AddPoint(DataPoint{Timestamp: t, Value: val})
 		}
 	}
@@ -301,7 +284,12 @@ func (ts *TimeSeries) Resample(delta time.Duration, f func(DataPoint, DataPoint,
  * @return A new TimeSeries containing all original points plus default-filled points. If delta <= 0, returns a copy. Empty input returns empty.
  */
 func (ts *TimeSeries) ResampleWithDefaultValue(delta time.Duration, defaultValue float64) TimeSeries {
-	return ts.Resample(delta, func(d1 DataPoint, d2 DataPoint, idx time.Time) float64 {
+	return ts.ResampleWithDefaultValueAndFrequency(Every(delta), defaultValue)
+}
+
+// ResampleWithDefaultValueAndFrequency is ResampleWithDefaultValue on the grid of a Frequency.
+func (ts *TimeSeries) ResampleWithDefaultValueAndFrequency(freq Frequency, defaultValue float64) TimeSeries {
+	return ts.ResampleWithFrequency(freq, func(d1 DataPoint, d2 DataPoint, idx time.Time) float64 {
 		return defaultValue
 	})
 }
@@ -314,7 +302,12 @@ func (ts *TimeSeries) ResampleWithDefaultValue(delta time.Duration, defaultValue
  * @return A new TimeSeries on a regular grid, with missing values linearly interpolated. If delta <= 0, returns a copy. Empty input returns empty.
  */
 func (ts *TimeSeries) Interpolate(delta time.Duration) TimeSeries {
-	return ts.Resample(delta, func(d1 DataPoint, d2 DataPoint, idx time.Time) float64 {
+	return ts.InterpolateWithFrequency(Every(delta))
+}
+
+// InterpolateWithFrequency is Interpolate on the grid of a Frequency.
+func (ts *TimeSeries) InterpolateWithFrequency(freq Frequency) TimeSeries {
+	return ts.ResampleWithFrequency(freq, func(d1 DataPoint, d2 DataPoint, idx time.Time) float64 {
 		total := d2.Timestamp.Sub(d1.Timestamp).Seconds()
 		if total == 0 {
 			return d1.Value
@@ -334,15 +327,28 @@ func (ts *TimeSeries) Interpolate(delta time.Duration) TimeSeries {
  *         If delta <= 0, returns a copy. Empty input returns empty.
  */
 func (ts *TimeSeries) Step(delta time.Duration) TimeSeries {
+	return ts.StepWithFrequency(Every(delta))
+}
+
+/**
+ * Steps the TimeSeries on the grid of a Frequency. Each value is split across the ticks up to the
+ * next point in proportion to the time each tick covers, so a monthly grid weighs a 31-day month
+ * more than February.
+ *
+ * @param freq The grid to step on; ticks follow freq.Floor of each point.
+ *
+ * @return A new TimeSeries as described for Step. If freq does not advance, returns a copy.
+ */
+func (ts *TimeSeries) StepWithFrequency(freq Frequency) TimeSeries {
 	if ts.IsEmpty() {
-		return Empty()
+		return ts.derive()
 	}
-	if delta <= 0 {
-		return FromDataPoints(ts.DataPoints())
+	points := ts.DataPoints()
+	if !advances(freq, points[0].Timestamp) {
+		return ts.withPoints(points)
 	}
 
-	points := ts.DataPoints()
-	result := EmptyLabeled(ts.label + " step")
+	result := ts.derive()
 
 	for i := 0; i < len(points)-1; i++ {
 		prev := points[i]
@@ -352,15 +358,17 @@ func (ts *TimeSeries) Step(delta time.Duration) TimeSeries {
 		if gap <= 0 {
 			continue
 		}
-		steps := int(gap / delta)
-		if steps == 0 {
-			continue
-		}
 
-		fraction := prev.Value * delta.Seconds() / gap.Seconds()
-		for j := 1; j <= steps; j++ {
-			tsAt := prev.Timestamp.Add(time.Duration(j) * delta)
+		covered := prev.Timestamp
+		base := freq.Floor(prev.Timestamp)
+		for j := 1; ; j++ {
+			tsAt := freq.Shift(base, j)
+			if tsAt.After(next.Timestamp) {
+				break
+			}
+			fraction := prev.Value * tsAt.Sub(covered).Seconds() / gap.Seconds()
 			result.AddPoint(DataPoint{Timestamp: tsAt, Value: fraction})
+			covered = tsAt
 		}
 	}
 
@@ -375,36 +383,19 @@ func (ts *TimeSeries) Step(delta time.Duration) TimeSeries {
  * @return A new TimeSeries with grouped timestamps and aggregated values.
  */
 func (ts *TimeSeries) GroupByTime(g func(dt time.Time) time.Time, f func(dp []DataPoint) float64) TimeSeries {
-	if ts.IsEmpty() {
-		return Empty()
-	} else {
-		var grouped [][]DataPoint
-		for _, dp := range ts.datapoints {
-			groupedKey := g(dp.Timestamp)
-			idx, err := findIndexInGroup(grouped, groupedKey)
-			if err == nil {
-				grouped[idx] = append(grouped[idx], dp)
-			} else {
-				grouped = append(grouped, []DataPoint{dp})
-			}
-		}
-		var result []DataPoint
-		for _, group := range grouped {
-			result = append(result, DataPoint{Timestamp: g(group[0].Timestamp), Value: f(group)})
-
-		}
-		return TimeSeries{result, ts.label + " grouped"}
-	}
+	return TimeSeries(GroupSeriesByTime(ts.AsSeries(), g, f))
 }
 
 func (ts TimeSeries) RollingWindow(window time.Duration, f func(vs []float64) float64) TimeSeries {
-	return ts.Map(func(dp DataPoint) DataPoint {
-		ws := ts.Filter(func(dp2 DataPoint) bool {
-			return (dp2.Timestamp.Before(dp.Timestamp) && dp2.Timestamp.After(dp.Timestamp.Add(-window))) || dp2.Timestamp.Equal(dp.Timestamp)
-		})
-		v := f(ws.Values())
-		return DataPoint{Timestamp: dp.Timestamp, Value: v}
-	})
+	return ts.RollingWindowWithFrequency(Every(window), f)
+}
+
+/**
+ * Applies f to the values in the window (t - one period of freq, t] at every point,
+ * e.g. MonthStart(1) for a trailing calendar month. See Rolling for the incremental statistics.
+ */
+func (ts TimeSeries) RollingWindowWithFrequency(freq Frequency, f func(vs []float64) float64) TimeSeries {
+	return ts.Rolling(TimeWindowWithFrequency(freq)).Apply(f)
 }
 
 /**
@@ -416,29 +407,32 @@ func (ts TimeSeries) RollingWindow(window time.Duration, f func(vs []float64) fl
  * @return A new TimeSeries containing all DataPoints from both TimeSeries in chronological order.
  */
 func (ts *TimeSeries) Merge(otherTS TimeSeries) TimeSeries {
-	merged := Empty()
+	merged := ts.derive()
+	if merged.loc == nil {
+		merged.loc = otherTS.loc
+	}
 	tsi, otsi := 0, 0
 	for tsi < ts.Length() && otsi < otherTS.Length() {
-		if ts.datapoints[tsi].Timestamp.Before(otherTS.datapoints[otsi].Timestamp) {
-			merged.AddPoint(ts.datapoints[tsi])
+		if ts.times[tsi] < otherTS.times[otsi] {
+			merged.appendRaw(ts.times[tsi], ts.values[tsi])
 			tsi++
-		} else if ts.datapoints[tsi].Timestamp.Equal(otherTS.datapoints[otsi].Timestamp) {
-			merged.AddPoint(ts.datapoints[tsi])
+		} else if ts.times[tsi] == otherTS.times[otsi] {
+			merged.appendRaw(ts.times[tsi], ts.values[tsi])
 			tsi++
 			otsi++
 		} else {
-			merged.AddPoint(otherTS.datapoints[otsi])
+			merged.appendRaw(otherTS.times[otsi], otherTS.values[otsi])
 			otsi++
 		}
 	}
 
 	for tsi < ts.Length() {
-		merged.AddPoint(ts.datapoints[tsi])
+		merged.appendRaw(ts.times[tsi], ts.values[tsi])
 		tsi++
 	}
 
 	for otsi < otherTS.Length() {
-		merged.AddPoint(otherTS.datapoints[otsi])
+		merged.appendRaw(otherTS.times[otsi], otherTS.values[otsi])
 		otsi++
 	}
 
@@ -447,6 +441,8 @@ func (ts *TimeSeries) Merge(otherTS TimeSeries) TimeSeries {
 
 /**
  * Joins (inner) two TimeSeries on their timestamps.
+ * All joins are a single merge pass over sorted inputs and return chronological output.
+ * Duplicate timestamps pair up as a cross product, left-major, like SQL joins.
  *
  * @param otherTS The other TimeSeries to join with.
  *
@@ -454,21 +450,10 @@ func (ts *TimeSeries) Merge(otherTS TimeSeries) TimeSeries {
  */
 func (ts *TimeSeries) Join(otherTS TimeSeries) AlignedSeries {
 	if ts.IsEmpty() || otherTS.IsEmpty() {
-		return EmptyLabeledAlignedSeries("empty series")
+		return alignedFrom(ts, "empty series")
 	} else {
-		res := EmptyLabeledAlignedSeries(ts.label + " joined with " + otherTS.label)
-
-		for _, leftValue := range ts.datapoints {
-			for _, rightValue := range otherTS.datapoints {
-				if leftValue.Timestamp.Equal(rightValue.Timestamp) {
-					res.datapoints = append(res.datapoints, DoubleDataPoint{
-						Timestamp:  leftValue.Timestamp,
-						LeftValue:  leftValue.Value,
-						RightValue: rightValue.Value,
-					})
-				}
-			}
-		}
+		res := alignedFrom(ts, ts.label+" joined with "+otherTS.label)
+		res.datapoints = mergeJoin(ts, &otherTS, false, false, 0, 0)
 		return res
 	}
 }
@@ -483,84 +468,41 @@ func (ts *TimeSeries) Join(otherTS TimeSeries) AlignedSeries {
  */
 func (ts *TimeSeries) JoinLeft(otherTS TimeSeries, defaultValue float64) AlignedSeries {
 	if ts.IsEmpty() {
-		return EmptyLabeledAlignedSeries("empty series")
+		return alignedFrom(ts, "empty series")
 	} else {
-		res := EmptyLabeledAlignedSeries(ts.label + " joined with " + otherTS.label)
-
-		for _, leftValue := range ts.datapoints {
-			matched := false
-			for _, rightValue := range otherTS.datapoints {
-				if leftValue.Timestamp.Equal(rightValue.Timestamp) {
-					res.datapoints = append(res.datapoints, DoubleDataPoint{
-						Timestamp:  leftValue.Timestamp,
-						LeftValue:  leftValue.Value,
-						RightValue: rightValue.Value,
-					})
-					matched = true
-					break
-				}
-			}
-			if !matched {
-				res.datapoints = append(res.datapoints, DoubleDataPoint{
-					Timestamp:  leftValue.Timestamp,
-					LeftValue:  leftValue.Value,
-					RightValue: defaultValue,
-				})
-			}
-		}
+		res := alignedFrom(ts, ts.label+" joined with "+otherTS.label)
+		res.datapoints = mergeJoin(ts, &otherTS, true, false, 0, defaultValue)
 		return res
 	}
 }
 
 /**
  * Joins (outer) two TimeSeries on their timestamps, filling missing values with defaults.
+ * The result is in chronological order.
  */
 func (ts *TimeSeries) JoinOuter(otherTS TimeSeries, defaultLeftValue float64, defaultRightValue float64) AlignedSeries {
 	if ts.IsEmpty() && otherTS.IsEmpty() {
-		return EmptyLabeledAlignedSeries("empty series")
+		return alignedFrom(ts, "empty series")
 	} else {
-		res := EmptyLabeledAlignedSeries(ts.label + " joined with " + otherTS.label)
-		for _, leftValue := range ts.datapoints {
-			matched := false
-			for _, rightValue := range otherTS.datapoints {
-				if leftValue.Timestamp.Equal(rightValue.Timestamp) {
-					res.datapoints = append(res.datapoints, DoubleDataPoint{
-						Timestamp:  leftValue.Timestamp,
-						LeftValue:  leftValue.Value,
-						RightValue: rightValue.Value,
-					})
-					matched = true
-					break
-				}
-			}
-			if !matched {
-				res.datapoints = append(res.datapoints, DoubleDataPoint{
-					Timestamp:  leftValue.Timestamp,
-					LeftValue:  leftValue.Value,
-					RightValue: defaultRightValue,
-				})
-			}
-		}
-		for _, rightValue := range otherTS.datapoints {
-			matched := false
-			for _, leftValue := range ts.datapoints {
-				if rightValue.Timestamp.Equal(leftValue.Timestamp) {
-					matched = true
-					break
-				}
-			}
-			if !matched {
-				res.datapoints = append(res.datapoints, DoubleDataPoint{
-					Timestamp:  rightValue.Timestamp,
-					LeftValue:  defaultLeftValue,
-					RightValue: rightValue.Value,
-				})
-			}
-		}
+		res := alignedFrom(ts, ts.label+" joined with "+otherTS.label)
+		res.datapoints = mergeJoin(ts, &otherTS, true, true, defaultLeftValue, defaultRightValue)
 		return res
 	}
 }
 
+// mergeJoin collects the merge of two sorted series into aligned datapoints.
+func mergeJoin(left, right *TimeSeries, keepLeft, keepRight bool, defaultLeft, defaultRight float64) []DoubleDataPoint {
+	res := make([]DoubleDataPoint, 0, max(left.Length(), right.Length()))
+	loc := left.core().Location()
+	if left.IsEmpty() {
+		loc = right.core().Location()
+	}
+	mergeJoinSeries(left.core(), right.core(), keepLeft, keepRight, defaultLeft, defaultRight, func(t int64, l, r float64) {
+		res = append(res, DoubleDataPoint{Timestamp: time.Unix(0, t).In(loc), LeftValue: l, RightValue: r})
+	})
+	return res
+}
+
 /**
 * Statistics
  */
@@ -574,13 +516,13 @@ func (ts *TimeSeries) Min() (DataPoint, error) {
 	if ts.IsEmpty() {
 		return DataPoint{}, errors.New("timeseries is empty")
 	}
-	minDP := ts.datapoints[0]
-	for _, dp := range ts.datapoints {
-		if dp.Value < minDP.Value {
-			minDP = dp
+	minIdx := 0
+	for i, v := range ts.values {
+		if v < ts.values[minIdx] {
+			minIdx = i
 		}
 	}
-	return minDP, nil
+	return ts.core().pointAt(minIdx), nil
 }
 
 /**
@@ -593,8 +535,8 @@ func (ts *TimeSeries) Sum() float64 {
 		return 0.0
 	}
 	sum := 0.0
-	for _, dp := range ts.datapoints {
-		sum += dp.Value
+	for _, v := range ts.values {
+		sum += v
 	}
 	return sum
 }
@@ -608,34 +550,25 @@ func (ts *TimeSeries) Max() (DataPoint, error) {
 	if ts.IsEmpty() {
 		return DataPoint{}, errors.New("timeseries is empty")
 	}
-	maxDP := ts.datapoints[0]
-	for _, dp := range ts.datapoints {
-		if dp.Value > maxDP.Value {
-			maxDP = dp
+	maxIdx := 0
+	for i, v := range ts.values {
+		if v > ts.values[maxIdx] {
+			maxIdx = i
 		}
 	}
-	return maxDP, nil
+	return ts.core().pointAt(maxIdx), nil
 }
 
+/**
+ * Returns the p-th percentile (0-100) of the values, ignoring NaN: Quantile with QuantileType6.
+ *
+ * @return The percentile, or an error if the TimeSeries is empty or p is outside [0, 100].
+ */
 func (ts *TimeSeries) Percentile(p int) (float64, error) {
 	if ts.IsEmpty() {
 		return 0.0, errors.New("timeseries is empty")
 	}
-	vs := ts.Values()
-	vsLen := len(vs)
-	pos := float64(p*(vsLen+1)) / 100
-	if pos < 1 {
-		return vs[0], nil
-	} else if pos >= float64(vsLen) {
-		return vs[vsLen-1], nil
-	} else {
-		pf := int(math.Floor(pos))
-		lower := vs[pf-1]
-		upper := vs[pf]
-		d := pos - math.Floor(pos)
-		p := lower + d*(upper-lower)
-		return p, nil
-	}
+	return ts.Quantile(float64(p)/100, QuantileType6)
 }
 
 /**
@@ -643,18 +576,13 @@ func (ts *TimeSeries) Percentile(p int) (float64, error) {
 *
  */
 func (ts *TimeSeries) Differentiate() TimeSeries {
-	result := Empty()
+	result := ts.derive()
 	if ts.Length() < 2 {
 		return result
 	}
 
-	prev, _ := ts.Head()
-
-	tail := ts.Tail()
-
-	for _, dp := range tail.datapoints {
-		result.AddPoint(DataPoint{dp.Timestamp, dp.Value - prev.Value})
-		prev = dp
+	for i := 1; i < len(ts.times); i++ {
+		result.appendRaw(ts.times[i], ts.values[i]-ts.values[i-1])
 	}
 
 	return result
@@ -662,23 +590,18 @@ func (ts *TimeSeries) Differentiate() TimeSeries {
 }
 
 /**
-* Return new series with sum between 2 points
-*
+* Return new series with sum between 2 points.
+* This is neither a cumulative sum nor an integral; see CumSum and IntegrateOverTime.
  */
 func (ts *TimeSeries) Integrate() TimeSeries {
-	result := Empty()
+	result := ts.derive()
 
 	if ts.Length() < 2 {
 		return result
 	}
 
-	prev := ts.datapoints[0]
-
-	tail := ts.Tail()
-
-	for _, dp := range tail.datapoints {
-		result.AddPoint(DataPoint{dp.Timestamp, dp.Value + prev.Value})
-		prev = dp
+	for i := 1; i < len(ts.times); i++ {
+		result.appendRaw(ts.times[i], ts.values[i]+ts.values[i-1])
 	}
 
 	return result
@@ -688,15 +611,3 @@ func (ts *TimeSeries) Integrate() TimeSeries {
 func (ts *TimeSeries) Median() (float64, error) {
 	return ts.Percentile(50)
 }
-
-func findIndexInGroup(grouped [][]DataPoint, key time.Time) (int, error) {
-	for i, k := range grouped {
-		if len(k) == 0 {
-			return -1, errors.New("empty group encountered")
-		}
-		if k[0].Timestamp.Equal(key) {
-			return i, nil
-		}
-	}
-	return -1, errors.New("key not found in groups")
-}
@@ -191,11 +191,11 @@ func TestSlice(t *testing.T) {
 		t.Errorf("Expected sliced TimeSeries length 4, got %d", res.Length())
 	}
 
-	if res.DataPoints()[0].Timestamp != start {
+	if !res.DataPoints()[0].Timestamp.Equal(start) {
 		t.Errorf("Expected first datapoint timestamp %v, got %v", start, res.DataPoints()[0].Timestamp)
 	}
 
-	if res.DataPoints()[3].Timestamp != now.Add(5*time.Minute) {
+	if !res.DataPoints()[3].Timestamp.Equal(now.Add(5 * time.Minute)) {
 		t.Errorf("Expected last datapoint timestamp %v, got %v", now.Add(5*time.Minute), res.DataPoints()[3].Timestamp)
 	}
 }
@@ -673,3 +673,54 @@ func TestStepCarriesForward(t *testing.T) {
 		}
 	}
 }
+
+func TestJoinOuterIsChronological(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts1 := Empty()
+	ts2 := Empty()
+
+	ts1.AddPoint(DataPoint{base.Add(2 * time.Hour), 2.0})
+	ts1.AddPoint(DataPoint{base.Add(4 * time.Hour), 4.0})
+
+	ts2.AddPoint(DataPoint{base.Add(1 * time.Hour), 10.0})
+	ts2.AddPoint(DataPoint{base.Add(3 * time.Hour), 30.0})
+	ts2.AddPoint(DataPoint{base.Add(4 * time.Hour), 40.0})
+
+	joined := ts1.JoinOuter(ts2, -1, -2)
+	expected := []DoubleDataPoint{
+		{Timestamp: base.Add(1 * time.Hour), LeftValue: -1, RightValue: 10},
+		{Timestamp: base.Add(2 * time.Hour), LeftValue: 2, RightValue: -2},
+		{Timestamp: base.Add(3 * time.Hour), LeftValue: -1, RightValue: 30},
+		{Timestamp: base.Add(4 * time.Hour), LeftValue: 4, RightValue: 40},
+	}
+	if joined.Length() != len(expected) {
+		t.Fatalf("Expected %d points, got %d", len(expected), joined.Length())
+	}
+	for i, dp := range joined.DataPoints() {
+		if dp != expected[i] {
+			t.Errorf("At index %d, expected %+v, got %+v", i, expected[i], dp)
+		}
+	}
+}
+
+func TestJoinDuplicateTimestampsProduceCrossProduct(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ts1 := Empty()
+	ts2 := Empty()
+
+	ts1.AddPoint(DataPoint{base, 1.0})
+	ts1.AddPoint(DataPoint{base, 2.0})
+	ts2.AddPoint(DataPoint{base, 10.0})
+	ts2.AddPoint(DataPoint{base, 20.0})
+
+	joined := ts1.Join(ts2)
+	expected := [][2]float64{{1, 10}, {1, 20}, {2, 10}, {2, 20}}
+	if joined.Length() != len(expected) {
+		t.Fatalf("Expected %d points, got %d", len(expected), joined.Length())
+	}
+	for i, dp := range joined.DataPoints() {
+		if dp.LeftValue != expected[i][0] || dp.RightValue != expected[i][1] {
+			t.Errorf("At index %d, expected %v, got %+v", i, expected[i], dp)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package timeseriesgo
+
+import (
+	"maps"
+	"time"
+)
+
+/**
+ * Returns the series with its timestamps reported in loc. The instants do not change,
+ * only the wall clock they are shown in, so this is cheap: the columns are shared.
+ */
+func (s *Series[T]) In(loc *time.Location) Series[T] {
+	return Series[T]{times: s.UnixNanosView(), values: s.ValuesView(), loc: loc, label: s.label, tags: maps.Clone(s.tags), unit: s.unit}
+}
+
+// UTC returns the series with its timestamps reported in UTC.
+func (s *Series[T]) UTC() Series[T] {
+	return s.In(time.UTC)
+}
+
+// In is Series.In for float64 values.
+func (ts *TimeSeries) In(loc *time.Location) TimeSeries {
+	return TimeSeries(ts.core().In(loc))
+}
+
+// UTC is Series.UTC for float64 values.
+func (ts *TimeSeries) UTC() TimeSeries {
+	return TimeSeries(ts.core().UTC())
+}
+
+// In returns the frame with its index reported in loc, sharing the columns.
+func (ms *MultiSeries) In(loc *time.Location) MultiSeries {
+	res := *ms
+	res.loc = loc
+	return res
+}
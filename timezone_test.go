@@ -0,0 +1,38 @@
+package timeseriesgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInAndUTC(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	ts := EmptyLabeled("temp")
+	ts.SetUnit("C")
+	ts.AddPoint(DataPoint{time.Date(2024, 6, 1, 9, 0, 0, 0, loc), 20})
+	ts.AddPoint(DataPoint{time.Date(2024, 6, 1, 10, 0, 0, 0, loc), 21})
+
+	utc := ts.UTC()
+	first := utc.Timestamps()[0]
+	if first.Location() != time.UTC || first.Hour() != 0 {
+		t.Errorf("expected 00:00 UTC, got %v", first)
+	}
+	if !first.Equal(ts.Timestamps()[0]) {
+		t.Errorf("converting must not change the instant")
+	}
+	if utc.Label() != "temp" || utc.Unit() != "C" {
+		t.Errorf("expected metadata to be kept")
+	}
+
+	back := utc.In(loc)
+	if back.Timestamps()[1].Hour() != 10 {
+		t.Errorf("expected 10:00 local, got %v", back.Timestamps()[1])
+	}
+	utc.AddPoint(DataPoint{time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC), 22})
+	if ts.Length() != 2 || back.Length() != 2 {
+		t.Errorf("appending to a converted series must not change the others")
+	}
+}
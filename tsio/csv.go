@@ -20,7 +20,7 @@ func FromStringWithTimeFormat(reader csv.Reader, timeFormat string, label string
 		return timeseriesgo.EmptyLabeled(label), err
 	}
 
-	ts := timeseriesgo.Empty()
+	ts := timeseriesgo.EmptyLabeled(label)
 	for _, row := range data {
 		if len(row) != 2 {
 			return timeseriesgo.Empty(), errors.New("expected exactly 2 columns per row")
@@ -0,0 +1,220 @@
+package tsio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	timeseriesgo "github.com/wenta/timeseries-go"
+)
+
+// AmbiguousPolicy resolves a local wall-clock time that occurs twice, e.g. 02:30 on the
+// night clocks go back.
+type AmbiguousPolicy int
+
+const (
+	// AmbiguousEarlier picks the first occurrence (the summer-time instant).
+	AmbiguousEarlier AmbiguousPolicy = iota
+	// AmbiguousLater picks the second occurrence.
+	AmbiguousLater
+	// AmbiguousInfer picks the first occurrence unless that would move backwards in time
+	// from the previous row (or repeat it), which is how the repeated hour appears in a chronological log.
+	AmbiguousInfer
+	// AmbiguousReject fails the parse.
+	AmbiguousReject
+)
+
+// NonExistentPolicy resolves a local wall-clock time that is skipped, e.g. 02:30 on the
+// night clocks go forward.
+type NonExistentPolicy int
+
+const (
+	// NonExistentShiftForward moves the time forward by the length of the gap (02:30 becomes 03:30).
+	NonExistentShiftForward NonExistentPolicy = iota
+	// NonExistentShiftBackward moves the time back by the length of the gap (02:30 becomes 01:30).
+	NonExistentShiftBackward
+	// NonExistentDrop leaves the row out.
+	NonExistentDrop
+	// NonExistentReject fails the parse.
+	NonExistentReject
+)
+
+// DSTPolicy decides what to do with wall-clock times that a DST change makes ambiguous or
+// non-existent. The zero value picks the earlier instant and shifts skipped times forward,
+// which is what time.Date does.
+type DSTPolicy struct {
+	Ambiguous   AmbiguousPolicy
+	NonExistent NonExistentPolicy
+}
+
+// DSTIssueKind tells ambiguous and non-existent wall-clock times apart.
+type DSTIssueKind int
+
+const (
+	DSTAmbiguous DSTIssueKind = iota
+	DSTNonExistent
+)
+
+func (k DSTIssueKind) String() string {
+	if k == DSTNonExistent {
+		return "non-existent"
+	}
+	return "ambiguous"
+}
+
+// DSTIssue reports one row whose wall-clock time needed the DST policy.
+type DSTIssue struct {
+	// Row is the zero-based CSV row.
+	Row int
+	// Text is the timestamp as written in the input.
+	Text string
+	Kind DSTIssueKind
+	// Resolved is the instant chosen by the policy; it is zero when the row was dropped.
+	Resolved time.Time
+	Dropped  bool
+}
+
+// ErrDST is wrapped by the errors returned for rows rejected by a DSTPolicy.
+var ErrDST = errors.New("DST wall-clock time rejected")
+
+/**
+ * Returns the instants that show the wall clock of wall (its date and clock, ignoring its
+ * location) in loc: one for a normal time, two (earlier first) for an ambiguous time, and
+ * none for a time skipped by a DST change.
+ */
+func WallClockInstants(wall time.Time, loc *time.Location) []time.Time {
+	naive, before, after := zoneOffsets(wall, loc)
+	var res []time.Time
+	for _, offset := range []int{before, after} {
+		t := naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		if sameWallClock(t, naive) && (len(res) == 0 || !res[0].Equal(t)) {
+			res = append(res, t)
+		}
+	}
+	if len(res) == 2 && res[1].Before(res[0]) {
+		res[0], res[1] = res[1], res[0]
+	}
+	return res
+}
+
+// zoneOffsets returns the wall clock of wall as a UTC time and the offsets (in seconds) of loc
+// a day before and after it. A DST change moves the offset at most once in that window.
+func zoneOffsets(wall time.Time, loc *time.Location) (naive time.Time, before, after int) {
+	y, mo, d := wall.Date()
+	h, mi, s := wall.Clock()
+	naive = time.Date(y, mo, d, h, mi, s, wall.Nanosecond(), time.UTC)
+	_, before = naive.Add(-24 * time.Hour).In(loc).Zone()
+	_, after = naive.Add(24 * time.Hour).In(loc).Zone()
+	return naive, before, after
+}
+
+func sameWallClock(t, naive time.Time) bool {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, mo, d, h, mi, s, t.Nanosecond(), time.UTC).Equal(naive)
+}
+
+// gapEdges returns the instants of a skipped wall-clock time read with the offsets in
+// force before and after the change.
+func gapEdges(wall time.Time, loc *time.Location) (forward, backward time.Time) {
+	naive, before, after := zoneOffsets(wall, loc)
+	return naive.Add(-time.Duration(before) * time.Second).In(loc), naive.Add(-time.Duration(after) * time.Second).In(loc)
+}
+
+// probeZone is an offset no real input uses; parsing with it reveals whether a timestamp carries its own zone.
+var probeZone = time.FixedZone("probe", 13*3600+17*60)
+
+/**
+ * Parses a CSV reader into a TimeSeries, reading timestamps without a zone as wall-clock
+ * times in loc. Timestamps that carry their own offset are kept as written and reported in loc.
+ * Expected columns per row: timestamp, value (float64). No header support.
+ *
+ * @param reader The CSV input.
+ * @param timeFormat The time layout, e.g. "2006-01-02 15:04:05".
+ * @param loc The location of the wall clock.
+ * @param policy How to resolve ambiguous and non-existent wall-clock times.
+ * @param label The name of the series.
+ *
+ * @return The series in loc, every row that needed the policy, and an error wrapping ErrDST
+ *         when the policy rejects a row.
+ */
+func FromStringInLocation(reader csv.Reader, timeFormat string, loc *time.Location, policy DSTPolicy, label string) (timeseriesgo.TimeSeries, []DSTIssue, error) {
+	data, err := reader.ReadAll()
+	if err != nil {
+		return timeseriesgo.EmptyLabeled(label), nil, err
+	}
+
+	ts := timeseriesgo.EmptyLabeled(label)
+	var issues []DSTIssue
+	var previous time.Time
+	for i, row := range data {
+		if len(row) != 2 {
+			return timeseriesgo.Empty(), issues, errors.New("expected exactly 2 columns per row")
+		}
+
+		wall, err := time.ParseInLocation(timeFormat, row[0], time.UTC)
+		if err != nil {
+			return timeseriesgo.Empty(), issues, err
+		}
+		val, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return timeseriesgo.Empty(), issues, err
+		}
+
+		probe, _ := time.ParseInLocation(timeFormat, row[0], probeZone)
+		if probe.Equal(wall) {
+			// The text carries its own offset.
+			previous = wall.In(loc)
+			ts.AddPoint(timeseriesgo.DataPoint{Timestamp: previous, Value: val})
+			continue
+		}
+
+		instants := WallClockInstants(wall, loc)
+		var dt time.Time
+		switch len(instants) {
+		case 1:
+			dt = instants[0]
+		case 2:
+			issue := DSTIssue{Row: i, Text: row[0], Kind: DSTAmbiguous}
+			switch policy.Ambiguous {
+			case AmbiguousReject:
+				return timeseriesgo.Empty(), append(issues, issue), fmt.Errorf("row %d: %s is ambiguous in %s: %w", i, row[0], loc, ErrDST)
+			case AmbiguousLater:
+				dt = instants[1]
+			case AmbiguousInfer:
+				dt = instants[0]
+				if !previous.IsZero() && !dt.After(previous) {
+					dt = instants[1]
+				}
+			default:
+				dt = instants[0]
+			}
+			issue.Resolved = dt
+			issues = append(issues, issue)
+		default:
+			issue := DSTIssue{Row: i, Text: row[0], Kind: DSTNonExistent}
+			forward, backward := gapEdges(wall, loc)
+			switch policy.NonExistent {
+			case NonExistentReject:
+				return timeseriesgo.Empty(), append(issues, issue), fmt.Errorf("row %d: %s does not exist in %s: %w", i, row[0], loc, ErrDST)
+			case NonExistentDrop:
+				issue.Dropped = true
+				issues = append(issues, issue)
+				continue
+			case NonExistentShiftBackward:
+				dt = backward
+			default:
+				dt = forward
+			}
+			issue.Resolved = dt
+			issues = append(issues, issue)
+		}
+
+		previous = dt
+		ts.AddPoint(timeseriesgo.DataPoint{Timestamp: dt, Value: val})
+	}
+
+	return ts, issues, nil
+}
@@ -0,0 +1,126 @@
+package tsio
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const wallLayout = "2006-01-02 15:04"
+
+func berlin(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	return loc
+}
+
+func TestFromStringInLocationResolvesAmbiguousHour(t *testing.T) {
+	loc := berlin(t)
+	// Clocks go back from 03:00 to 02:00 on 2024-10-27, so 02:30 appears twice.
+	input := "2024-10-27 01:30,1\n2024-10-27 02:30,2\n2024-10-27 02:30,3\n2024-10-27 03:30,4\n"
+
+	cases := []struct {
+		policy AmbiguousPolicy
+		want   []string // UTC clock of rows 1 and 2
+	}{
+		{AmbiguousEarlier, []string{"00:30", "00:30"}},
+		{AmbiguousLater, []string{"01:30", "01:30"}},
+		{AmbiguousInfer, []string{"00:30", "01:30"}},
+	}
+	for _, c := range cases {
+		reader := csv.NewReader(strings.NewReader(input))
+		ts, issues, err := FromStringInLocation(*reader, wallLayout, loc, DSTPolicy{Ambiguous: c.policy}, "load")
+		if err != nil {
+			t.Fatalf("policy %d: unexpected error %v", c.policy, err)
+		}
+		if len(issues) != 2 || issues[0].Kind != DSTAmbiguous || issues[0].Row != 1 {
+			t.Errorf("policy %d: expected two ambiguous rows, got %+v", c.policy, issues)
+		}
+		times := ts.Timestamps()
+		for k, want := range c.want {
+			if got := times[k+1].UTC().Format("15:04"); got != want {
+				t.Errorf("policy %d row %d: expected %s UTC, got %s", c.policy, k+1, want, got)
+			}
+		}
+		if times[0].Location() != loc {
+			t.Errorf("expected timestamps in %s, got %s", loc, times[0].Location())
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(input))
+	if _, _, err := FromStringInLocation(*reader, wallLayout, loc, DSTPolicy{Ambiguous: AmbiguousReject}, "load"); !errors.Is(err, ErrDST) {
+		t.Errorf("expected ErrDST, got %v", err)
+	}
+}
+
+func TestFromStringInLocationResolvesSkippedHour(t *testing.T) {
+	loc := berlin(t)
+	// Clocks go forward from 02:00 to 03:00 on 2024-03-31, so 02:30 does not exist.
+	input := "2024-03-31 01:30,1\n2024-03-31 02:30,2\n2024-03-31 03:30,3\n"
+
+	cases := []struct {
+		policy NonExistentPolicy
+		length int
+		want   string
+	}{
+		{NonExistentShiftForward, 3, "03:30"},
+		{NonExistentShiftBackward, 3, "01:30"},
+		{NonExistentDrop, 2, ""},
+	}
+	for _, c := range cases {
+		reader := csv.NewReader(strings.NewReader(input))
+		ts, issues, err := FromStringInLocation(*reader, wallLayout, loc, DSTPolicy{NonExistent: c.policy}, "load")
+		if err != nil {
+			t.Fatalf("policy %d: unexpected error %v", c.policy, err)
+		}
+		if len(issues) != 1 || issues[0].Kind != DSTNonExistent || issues[0].Text != "2024-03-31 02:30" {
+			t.Errorf("policy %d: expected one non-existent row, got %+v", c.policy, issues)
+		}
+		if ts.Length() != c.length {
+			t.Fatalf("policy %d: expected %d rows, got %d", c.policy, c.length, ts.Length())
+		}
+		if c.want != "" {
+			if got := ts.Timestamps()[1].Format("15:04"); got != c.want {
+				t.Errorf("policy %d: expected %s local, got %s", c.policy, c.want, got)
+			}
+		} else if !issues[0].Dropped {
+			t.Errorf("expected the row to be marked as dropped")
+		}
+	}
+
+	reader := csv.NewReader(strings.NewReader(input))
+	if _, _, err := FromStringInLocation(*reader, wallLayout, loc, DSTPolicy{NonExistent: NonExistentReject}, "load"); !errors.Is(err, ErrDST) {
+		t.Errorf("expected ErrDST, got %v", err)
+	}
+}
+
+func TestFromStringInLocationKeepsExplicitOffsets(t *testing.T) {
+	loc := berlin(t)
+	input := "2024-10-27T00:30:00Z,1\n2024-10-27T01:30:00Z,2\n"
+	reader := csv.NewReader(strings.NewReader(input))
+	ts, issues, err := FromStringInLocation(*reader, time.RFC3339, loc, DSTPolicy{Ambiguous: AmbiguousReject}, "load")
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("expected explicit offsets to bypass the policy, got %v and %+v", err, issues)
+	}
+	times := ts.Timestamps()
+	if !times[0].Equal(time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC)) || times[1].Format("15:04 MST") != "02:30 CET" {
+		t.Errorf("unexpected timestamps %v", times)
+	}
+}
+
+func TestWallClockInstants(t *testing.T) {
+	loc := berlin(t)
+	if n := len(WallClockInstants(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), loc)); n != 1 {
+		t.Errorf("expected one instant for a normal time, got %d", n)
+	}
+	if n := len(WallClockInstants(time.Date(2024, 10, 27, 2, 30, 0, 0, time.UTC), loc)); n != 2 {
+		t.Errorf("expected two instants for an ambiguous time, got %d", n)
+	}
+	if n := len(WallClockInstants(time.Date(2024, 3, 31, 2, 30, 0, 0, time.UTC), loc)); n != 0 {
+		t.Errorf("expected no instant for a skipped time, got %d", n)
+	}
+}